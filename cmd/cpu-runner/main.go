@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl/cpu6502"
+	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
+)
+
+// CPUだけで6502のプログラムを実行する
+// 同じアドレスへのジャンプ（PCトラップ）を検出したら停止する
+//
+// 例: Klaus Dormann's 6502 functional test
+//   go run cmd/cpu-runner/main.go -load 0x0000 -pc 0x0400 -success 0x3469 6502_functional_test.bin
+
+func main() {
+	load := flag.String("load", "0x0000", "load address of the binary")
+	pc := flag.String("pc", "0x0400", "start address")
+	success := flag.String("success", "", "PC of the success trap (if empty, any trap is treated as success)")
+	variant := flag.String("variant", "nmos", "cpu variant (nmos, 2a03)")
+	maxCycles := flag.Int("max-cycles", 0, "stop after the specified cycles (0: unlimited)")
+	trace := flag.Bool("trace", false, "print executed instructions")
+	flag.Parse()
+
+	log.SetOutput(os.Stdout)
+	log.SetLogLevel(log.LevelInfo)
+	log.SetEnableLevelLabel(false)
+	log.SetEnableTimestamp(false)
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: cpu-runner [options] <binary>")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	code, err := run(flag.Arg(0), *load, *pc, *success, *variant, *maxCycles, *trace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+	}
+	os.Exit(code)
+}
+
+func run(path, load, pc, success, variant string, maxCycles int, trace bool) (int, error) {
+	loadAddr, err := parseAddress(load)
+	if err != nil {
+		return 2, err
+	}
+	startPC, err := parseAddress(pc)
+	if err != nil {
+		return 2, err
+	}
+	var successPC *uint16
+	if success != "" {
+		v, err := parseAddress(success)
+		if err != nil {
+			return 2, err
+		}
+		successPC = &v
+	}
+
+	var v domain.CPUVariant
+	switch variant {
+	case "nmos":
+		v = domain.CPUVariantNMOS6502
+	case "2a03":
+		v = domain.CPUVariant2A03
+	default:
+		return 2, xerrors.Errorf("unknown variant: %s", variant)
+	}
+
+	bin, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 2, xerrors.Errorf(": %w", err)
+	}
+
+	ram := cpu6502.NewRAM()
+	ram.Load(domain.Address(loadAddr), bin)
+
+	recorder := &domain.Recorder{}
+	cpu := cpu6502.NewCPU(v, &startPC)
+	cpu.SetMemory(ram, nil)
+	cpu.SetRecorder(recorder)
+
+	// リセット
+	cycles, err := cpu.Run()
+	if err != nil {
+		return 1, xerrors.Errorf(": %w", err)
+	}
+
+	for maxCycles == 0 || cycles < maxCycles {
+		before := cpu.GetPC()
+
+		c, err := cpu.Run()
+		if err != nil {
+			return 1, xerrors.Errorf(": %w", err)
+		}
+		cycles = cycles + c
+		recorder.Cycle = cycles
+
+		if trace {
+			fmt.Println(recorder.String())
+		}
+
+		if cpu.IsJammed() {
+			fmt.Printf("jammed at %04X (cycles: %d)\n", before, cycles)
+			return 1, nil
+		}
+
+		if cpu.GetPC() != before {
+			continue
+		}
+
+		fmt.Printf("trapped at %04X (cycles: %d)\n%v\n", before, cycles, cpu.GetRegisters())
+		if successPC != nil && *successPC != before {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	fmt.Printf("cycle limit exceeded at %04X (cycles: %d)\n", cpu.GetPC(), cycles)
+	return 1, nil
+}
+
+func parseAddress(s string) (uint16, error) {
+	v, err := strconv.ParseUint(s, 0, 16)
+	if err != nil {
+		return 0, xerrors.Errorf("invalid address: %s", s)
+	}
+	return uint16(v), nil
+}
@@ -1,12 +1,13 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"nes-go/pkg/domain"
 	"nes-go/pkg/impl"
 	"nes-go/pkg/log"
 	"os"
-
-	"github.com/hajimehoshi/ebiten"
 )
 
 const (
@@ -18,6 +19,21 @@ const (
 )
 
 func main() {
+	fault := flag.String("fault", "break", "on cpu fault: halt, reset or break")
+	frameHandoff := flag.String("frame-handoff", "drop", "when the window has not shown the last frame: drop (replace it) or block (wait for the window)")
+	flag.Parse()
+
+	faultPolicy, err := domain.ParseFaultPolicy(*fault)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+	frameHandoffMode, err := domain.ParseFrameHandoffMode(*frameHandoff)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+
 	log.SetOutput(os.Stdout)
 	log.SetLogLevel(LOGLEVEL)
 	log.SetEnableLevelLabel(false)
@@ -33,42 +49,36 @@ func main() {
 		SCALE,
 		"nes-go",
 		ENABLE_DEBUG_PRINT,
+		frameHandoffMode,
 	)
 	if err != nil {
 		return
 	}
 
 	nes := domain.NES{
-		Bus:      bus,
-		CPU:      cpu,
-		PPU:      ppu,
-		Pad1:     makePad1(),
-		Pad2:     makePad2(),
-		Renderer: renderer,
-		Recorder: &domain.Recorder{},
+		Bus:         bus,
+		CPU:         cpu,
+		PPU:         ppu,
+		Pad1:        makePad(0),
+		Pad2:        makePad(1),
+		Renderer:    renderer,
+		Recorder:    &domain.Recorder{},
+		FaultPolicy: faultPolicy,
 	}
 	if err := nes.Setup(ROM_PATH); err != nil {
 		panic(err)
 	}
 
-	if err := nes.Run(); err != nil {
-		panic(err)
+	if err := nes.Run(context.Background()); err != nil {
+		log.Warn("%+v", err)
+		os.Exit(1)
 	}
 }
 
-func makePad1() domain.Pad {
-	return impl.NewPad(map[domain.ButtonType]ebiten.Key{
-		domain.ButtonTypeA:      ebiten.KeyA,
-		domain.ButtonTypeB:      ebiten.KeyB,
-		domain.ButtonTypeSelect: ebiten.KeyShift,
-		domain.ButtonTypeStart:  ebiten.KeySpace,
-		domain.ButtonTypeUp:     ebiten.KeyUp,
-		domain.ButtonTypeDown:   ebiten.KeyDown,
-		domain.ButtonTypeLeft:   ebiten.KeyLeft,
-		domain.ButtonTypeRight:  ebiten.KeyRight,
-	})
-}
-
-func makePad2() domain.Pad {
-	return impl.NewPad(map[domain.ButtonType]ebiten.Key{})
+func makePad(player int) domain.Pad {
+	m, err := impl.ParseKeyMapping(impl.DefaultKeyNames(player))
+	if err != nil {
+		panic(err)
+	}
+	return impl.NewPad(m)
 }
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"nes-go/pkg/domain"
+	"nes-go/pkg/log"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// config ... runサブコマンドの設定（設定ファイルのキーはフラグ名と同じ）
+type config struct {
+	Config         string     `json:"-"`
+	Scale          float64    `json:"scale"`
+	LogLevel       string     `json:"log-level"`
+	LogCategories  stringList `json:"log-categories"`
+	LogFuncName    bool       `json:"log-func-name"`
+	PC             string     `json:"pc"`
+	Region         string     `json:"region"`
+	GameDB         string     `json:"gamedb"`
+	Palette        string     `json:"palette"`
+	Keys           string     `json:"keys"`
+	Fullscreen     bool       `json:"fullscreen"`
+	DebugPrint     bool       `json:"debug-print"`
+	Mute           bool       `json:"mute"` // 音声出力を実装するまでは何もしない
+	Frames         int        `json:"frames"`
+	TurboRate      int        `json:"turbo-rate"`
+	Port2          string     `json:"port2"`
+	Multitap       string     `json:"multitap"`
+	Expansion      string     `json:"expansion"`
+	PaddleGamepad  int        `json:"paddle-gamepad"`
+	KeyboardMode   string     `json:"keyboard-mode"`
+	PowerPadSide   string     `json:"powerpad-side"`
+	Fault          string     `json:"fault"`
+	FrameHandoff   string     `json:"frame-handoff"`
+	RewindInterval int        `json:"rewind-interval"`
+	RewindMaxBytes int        `json:"rewind-max-bytes"`
+	Play           string     `json:"play"`
+	Record         string     `json:"record"`
+	State          string     `json:"state"`
+	Trace          string     `json:"trace"`
+}
+
+// defaultConfig ...
+func defaultConfig() *config {
+	return &config{
+		Scale:          2.5,
+		LogLevel:       "info",
+		DebugPrint:     true,
+		Fault:          "halt",
+		FrameHandoff:   "drop",
+		PaddleGamepad:  -1,
+		KeyboardMode:   "matrix",
+		PowerPadSide:   "b",
+		RewindInterval: domain.DefaultRewindInterval,
+		RewindMaxBytes: domain.DefaultRewindMaxBytes,
+	}
+}
+
+// newFlagSet ... cにフラグを割り当てたFlagSet
+func (c *config) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&c.Config, "config", c.Config, "JSON config file (flags override its values)")
+	fs.Float64Var(&c.Scale, "scale", c.Scale, "window scale")
+	fs.StringVar(&c.LogLevel, "log-level", c.LogLevel, "log level: trace, debug, info, warn or fatal")
+	fs.Var(&c.LogCategories, "log-categories", "comma separated log categories: package (cpu6502) or package/file (impl/ppu2)")
+	fs.BoolVar(&c.LogFuncName, "log-func-name", c.LogFuncName, "prefix log lines with the calling function name")
+	fs.StringVar(&c.PC, "pc", c.PC, "start PC such as 0xC000 (default: reset vector)")
+	fs.StringVar(&c.Region, "region", c.Region, "region: ntsc, pal or dendy (default: detect from rom)")
+	fs.StringVar(&c.GameDB, "gamedb", c.GameDB, "game database file used to detect the region: lines of \"CRC32 region title\"")
+	fs.StringVar(&c.Palette, "palette", c.Palette, ".pal palette file")
+	fs.StringVar(&c.Keys, "keys", c.Keys, "JSON key config file")
+	fs.BoolVar(&c.Fullscreen, "fullscreen", c.Fullscreen, "start in fullscreen")
+	fs.BoolVar(&c.DebugPrint, "debug-print", c.DebugPrint, "print fps on the screen")
+	fs.BoolVar(&c.Mute, "mute", c.Mute, "mute audio (no effect until audio output is implemented)")
+	fs.IntVar(&c.Frames, "frames", c.Frames, "run headless for the given number of frames and exit")
+	fs.IntVar(&c.TurboRate, "turbo-rate", c.TurboRate, "turbo buttons press once every given frames (default 4)")
+	fs.StringVar(&c.Port2, "port2", c.Port2, "device on port 2: pad, zapper (aim with the mouse, fire with the left button), vaus or powerpad")
+	fs.StringVar(&c.PowerPadSide, "powerpad-side", c.PowerPadSide, "side of the power pad mat: a (8 buttons) or b (12 buttons)")
+	fs.StringVar(&c.Multitap, "multitap", c.Multitap, "4 player adapter: none, fourscore or famicom")
+	fs.StringVar(&c.Expansion, "expansion", c.Expansion, "device on the famicom expansion port: none, vaus or keyboard (family basic, F12 switches the host keyboard)")
+	fs.StringVar(&c.KeyboardMode, "keyboard-mode", c.KeyboardMode, "family basic keyboard input: matrix (host keys as keys) or text (type host text input)")
+	fs.IntVar(&c.PaddleGamepad, "paddle-gamepad", c.PaddleGamepad, "control vaus with the left stick and A of the given gamepad device instead of the mouse")
+	fs.StringVar(&c.Fault, "fault", c.Fault, "on cpu fault: halt, reset or break")
+	fs.StringVar(&c.FrameHandoff, "frame-handoff", c.FrameHandoff, "when the window has not shown the last frame: drop (replace it) or block (wait for the window)")
+	fs.IntVar(&c.RewindInterval, "rewind-interval", c.RewindInterval, "save a rewind snapshot every given frames (0 disables rewind)")
+	fs.IntVar(&c.RewindMaxBytes, "rewind-max-bytes", c.RewindMaxBytes, "memory limit of rewind snapshots in bytes (older ones are dropped)")
+	fs.StringVar(&c.Play, "play", c.Play, "play .fm2 movie")
+	fs.StringVar(&c.Record, "record", c.Record, "record input and write it as .fm2 on exit (from power-on, or from the loaded state with -state)")
+	fs.StringVar(&c.State, "state", c.State, "load save state file at start")
+	fs.StringVar(&c.Trace, "trace", c.Trace, "write cpu trace in nestest.log format (- for stdout)")
+	return fs
+}
+
+// parseConfig ... フラグと設定ファイルを読み込み、残りの引数を返す
+func parseConfig(name string, args []string) (*config, []string, error) {
+	c := defaultConfig()
+	fs := c.newFlagSet(name)
+	if err := fs.Parse(args); err != nil {
+		// エラーと使い方はFlagSetが表示済み
+		return nil, nil, flag.ErrHelp
+	}
+	if c.Config == "" {
+		return c, fs.Args(), nil
+	}
+
+	fc := defaultConfig()
+	if err := fc.load(c.Config); err != nil {
+		return nil, nil, xerrors.Errorf(": %w", err)
+	}
+	fc.Config = c.Config
+
+	// 指定されたフラグで設定ファイルの値を上書きする
+	override := fc.newFlagSet(name)
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		if err == nil {
+			err = override.Set(f.Name, f.Value.String())
+		}
+	})
+	if err != nil {
+		return nil, nil, xerrors.Errorf(": %w", err)
+	}
+
+	return fc, fs.Args(), nil
+}
+
+// load ... JSONの設定ファイルを読み込む（知らないキーはエラー）
+func (c *config) load(p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	defer f.Close()
+
+	d := json.NewDecoder(f)
+	d.DisallowUnknownFields()
+	if err := d.Decode(c); err != nil {
+		return xerrors.Errorf("failed to read config: %v: %w", p, err)
+	}
+	return nil
+}
+
+// getLogLevel ...
+func (c *config) getLogLevel() (log.Level, error) {
+	l, err := log.ParseLevel(c.LogLevel)
+	if err != nil {
+		return l, xerrors.Errorf(": %w", err)
+	}
+	return l, nil
+}
+
+// getFirstPC ... 指定されていない場合はnil（リセットベクタから開始）
+func (c *config) getFirstPC() (*uint16, error) {
+	if c.PC == "" {
+		return nil, nil
+	}
+	pc, err := strconv.ParseUint(c.PC, 0, 16)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid pc: %v: %w", c.PC, err)
+	}
+	firstPC := uint16(pc)
+	return &firstPC, nil
+}
+
+// getRegion ... 指定されていない場合はnil（ROMから判定）
+func (c *config) getRegion() (*domain.Region, error) {
+	if c.Region == "" {
+		return nil, nil
+	}
+	r, err := domain.ParseRegion(c.Region)
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return &r, nil
+}
+
+// getMultitap ... 指定されていない場合はMultitapNone
+func (c *config) getMultitap() (domain.Multitap, error) {
+	if c.Multitap == "" {
+		return domain.MultitapNone, nil
+	}
+	m, err := domain.ParseMultitap(c.Multitap)
+	if err != nil {
+		return m, xerrors.Errorf(": %w", err)
+	}
+	return m, nil
+}
+
+// usesFamilyKeyboard ... ファミリーベーシックのキーボードを接続するか
+func (c *config) usesFamilyKeyboard() bool {
+	return strings.EqualFold(c.Expansion, "keyboard")
+}
+
+// isKeyboardTextMode ... ファミリーベーシックのキーボードをテキスト入力で操作するか
+func (c *config) isKeyboardTextMode() (bool, error) {
+	switch strings.ToLower(c.KeyboardMode) {
+	case "matrix":
+		return false, nil
+	case "text":
+		return true, nil
+	default:
+		return false, xerrors.Errorf("unknown keyboard mode: %v", c.KeyboardMode)
+	}
+}
+
+// getFaultPolicy ...
+func (c *config) getFaultPolicy() (domain.FaultPolicy, error) {
+	p, err := domain.ParseFaultPolicy(c.Fault)
+	if err != nil {
+		return p, xerrors.Errorf(": %w", err)
+	}
+	return p, nil
+}
+
+// getFrameHandoffMode ...
+func (c *config) getFrameHandoffMode() (domain.FrameHandoffMode, error) {
+	m, err := domain.ParseFrameHandoffMode(c.FrameHandoff)
+	if err != nil {
+		return m, xerrors.Errorf(": %w", err)
+	}
+	return m, nil
+}
+
+// getRewindConfig ... 巻き戻ししない場合はnil
+func (c *config) getRewindConfig() (*domain.RewindConfig, error) {
+	if c.RewindInterval < 0 {
+		return nil, xerrors.Errorf("invalid rewind interval: %v", c.RewindInterval)
+	}
+	if c.RewindMaxBytes <= 0 {
+		return nil, xerrors.Errorf("invalid rewind max bytes: %v", c.RewindMaxBytes)
+	}
+	if c.RewindInterval == 0 {
+		return nil, nil
+	}
+	return &domain.RewindConfig{
+		Interval: c.RewindInterval,
+		MaxBytes: c.RewindMaxBytes,
+	}, nil
+}
+
+// stringList ... カンマ区切りで指定するフラグ
+type stringList []string
+
+// String ...
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set ...
+func (l *stringList) Set(s string) error {
+	*l = stringList{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
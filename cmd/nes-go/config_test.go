@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	const configArg = "{config}" // 設定ファイルのパスに置き換える引数
+
+	tests := []struct {
+		name     string
+		file     string        // 設定ファイルの内容
+		args     []string      // configArgは設定ファイルのパスに置き換える
+		want     func(*config) // デフォルトの設定から変わる値
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name: "when config file is not specified, flags are used",
+			args: []string{"-scale", "3", "-log-categories", "cpu6502, impl/ppu2", "-mute", "rom.nes"},
+			want: func(c *config) {
+				c.Scale = 3
+				c.LogCategories = stringList{"cpu6502", "impl/ppu2"}
+				c.Mute = true
+			},
+			wantArgs: []string{"rom.nes"},
+		},
+		{
+			name: "when config file is specified, its values are used",
+			file: `{"scale": 3, "region": "pal", "log-categories": ["cpu6502"], "mute": true}`,
+			args: []string{"-config", configArg, "rom.nes"},
+			want: func(c *config) {
+				c.Scale = 3
+				c.Region = "pal"
+				c.LogCategories = stringList{"cpu6502"}
+				c.Mute = true
+			},
+			wantArgs: []string{"rom.nes"},
+		},
+		{
+			name: "when flags are specified with config file, flags override the file",
+			file: `{"scale": 3, "region": "pal", "log-categories": ["cpu6502", "impl/ppu2"], "fullscreen": true}`,
+			args: []string{"-scale", "4", "-config", configArg, "-log-categories", "impl/bus", "-fullscreen=false", "rom.nes"},
+			want: func(c *config) {
+				c.Scale = 4
+				c.Region = "pal"
+				c.LogCategories = stringList{"impl/bus"}
+				c.Fullscreen = false
+			},
+			wantArgs: []string{"rom.nes"},
+		},
+		{
+			name:    "when config file has an unknown key, return error",
+			file:    `{"scale": 3, "sacle": 4}`,
+			args:    []string{"-config", configArg, "rom.nes"},
+			wantErr: true,
+		},
+		{
+			name:    "when config file has a wrong type, return error",
+			file:    `{"scale": "3"}`,
+			args:    []string{"-config", configArg, "rom.nes"},
+			wantErr: true,
+		},
+		{
+			name:    "when config file does not exist, return error",
+			args:    []string{"-config", filepath.Join(dir, "not-found.json"), "rom.nes"},
+			wantErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := filepath.Join(dir, fmt.Sprintf("config%v.json", i))
+			if tt.file != "" {
+				if err := ioutil.WriteFile(p, []byte(tt.file), 0644); err != nil {
+					t.Fatalf("failed to write config: %v", err)
+				}
+			}
+			args := []string{}
+			for _, a := range tt.args {
+				if a == configArg {
+					a = p
+				}
+				args = append(args, a)
+			}
+
+			got, gotArgs, err := parseConfig("run", args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wrong error\nwantErr: %#v\ngot: %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			want := defaultConfig()
+			tt.want(want)
+			if tt.file != "" {
+				want.Config = p
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("wrong args\nwant: %#v\ngot: %#v", tt.wantArgs, gotArgs)
+			}
+		})
+	}
+}
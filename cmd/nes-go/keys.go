@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl"
+	"os"
+
+	"github.com/hajimehoshi/ebiten"
+	"golang.org/x/xerrors"
+)
+
+// keyConfig ... プレイヤーごとのキーボード・ゲームパッドの割り当て
+// 例: {"pad1": {"A": "X", "B": "Z", "START": ["Enter", "Space"]}, "gamepad1": {"device": 0, "profile": "standard"}}
+// 指定したプレイヤーは割り当てをすべて置き換え、指定していないプレイヤーはデフォルトの割り当てを使う
+// 3P・4Pは-multitapを指定した場合のみ使う
+// keyboardはファミリーベーシックのキーボード（-expansion keyboard）の割り当てで、指定したキーだけデフォルトから置き換える
+// powerpadはPower Pad（-port2 powerpad）の使う面のボタンの番号ごとの割り当てで、指定した場合はすべて置き換える
+type keyConfig struct {
+	Pad1            map[string]keyNames             `json:"pad1"`
+	Pad2            map[string]keyNames             `json:"pad2"`
+	Pad3            map[string]keyNames             `json:"pad3"`
+	Pad4            map[string]keyNames             `json:"pad4"`
+	Gamepad1        *gamepadConfig                  `json:"gamepad1"`
+	Gamepad2        *gamepadConfig                  `json:"gamepad2"`
+	Gamepad3        *gamepadConfig                  `json:"gamepad3"`
+	Gamepad4        *gamepadConfig                  `json:"gamepad4"`
+	GamepadProfiles map[string]gamepadProfileConfig `json:"gamepad-profiles"`
+	Keyboard        map[string]keyNames             `json:"keyboard"`
+	PowerPad        map[string]keyNames             `json:"powerpad"`
+}
+
+// players ... 設定できるプレイヤー数
+const players = 4
+
+// standardGamepadProfile ... 組み込みのゲームパッドの割り当ての名前
+const standardGamepadProfile = "standard"
+
+// gamepadConfig ... プレイヤーが使うゲームパッド
+type gamepadConfig struct {
+	Device  int    `json:"device"`  // 接続順の番号（-1の場合はゲームパッドを使わない）
+	Profile string `json:"profile"` // gamepad-profilesの名前（空の場合はstandard）
+}
+
+// defaultGamepadConfigs ... 1Pは1台目、2Pは2台目、3Pは3台目、4Pは4台目のゲームパッドを使う
+var defaultGamepadConfigs = [players]gamepadConfig{
+	{Device: 0, Profile: standardGamepadProfile},
+	{Device: 1, Profile: standardGamepadProfile},
+	{Device: 2, Profile: standardGamepadProfile},
+	{Device: 3, Profile: standardGamepadProfile},
+}
+
+// gamepadProfileConfig ... ゲームパッドのボタン・軸の割り当て
+// 例: {"buttons": {"A": [1], "B": [0, 2]}, "axes": [{"axis": 0, "negative": "LEFT", "positive": "RIGHT"}], "deadzone": 0.4}
+type gamepadProfileConfig struct {
+	Buttons  map[string][]int    `json:"buttons"`
+	Axes     []gamepadAxisConfig `json:"axes"`
+	Deadzone *float64            `json:"deadzone"` // nilの場合はデフォルト
+}
+
+// gamepadAxisConfig ...
+type gamepadAxisConfig struct {
+	Axis     int    `json:"axis"`
+	Negative string `json:"negative"`
+	Positive string `json:"positive"`
+}
+
+// toProfile ...
+func (c gamepadProfileConfig) toProfile() (impl.GamepadProfile, error) {
+	p := impl.GamepadProfile{
+		Buttons:  map[domain.ButtonType][]ebiten.GamepadButton{},
+		Deadzone: impl.DefaultGamepadDeadzone,
+	}
+	if c.Deadzone != nil {
+		p.Deadzone = *c.Deadzone
+	}
+
+	for name, buttons := range c.Buttons {
+		b, err := domain.ParseButtonType(name)
+		if err != nil {
+			return p, xerrors.Errorf(": %w", err)
+		}
+		for _, gb := range buttons {
+			p.Buttons[b] = append(p.Buttons[b], ebiten.GamepadButton(gb))
+		}
+	}
+
+	for _, a := range c.Axes {
+		axis := impl.GamepadAxis{Axis: a.Axis}
+		var err error
+		if a.Negative != "" {
+			if axis.Negative, err = domain.ParseButtonType(a.Negative); err != nil {
+				return p, xerrors.Errorf("axis %v: %w", a.Axis, err)
+			}
+		}
+		if a.Positive != "" {
+			if axis.Positive, err = domain.ParseButtonType(a.Positive); err != nil {
+				return p, xerrors.Errorf("axis %v: %w", a.Axis, err)
+			}
+		}
+		p.Axes = append(p.Axes, axis)
+	}
+
+	if err := p.Validate(); err != nil {
+		return p, xerrors.Errorf(": %w", err)
+	}
+	return p, nil
+}
+
+// keyNames ... 1つのボタンに割り当てるキー
+type keyNames []string
+
+// UnmarshalJSON ... キー名1つの場合は配列にしなくてもよい
+func (k *keyNames) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*k = keyNames{name}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return xerrors.Errorf("key must be a string or an array of strings: %s", data)
+	}
+	*k = keyNames(names)
+	return nil
+}
+
+// loadKeyMappings ... 1P-4Pのキーボードの割り当てを返す
+func loadKeyMappings(kc *keyConfig) ([players]impl.KeyMapping, error) {
+	var names [players]map[string][]string
+	for i := range names {
+		names[i] = impl.DefaultKeyNames(i)
+	}
+	for i, pad := range []map[string]keyNames{kc.Pad1, kc.Pad2, kc.Pad3, kc.Pad4} {
+		if pad == nil {
+			continue
+		}
+		names[i] = map[string][]string{}
+		for b, k := range pad {
+			names[i][b] = k
+		}
+	}
+
+	var mappings [players]impl.KeyMapping
+	for i := range names {
+		m, err := impl.ParseKeyMapping(names[i])
+		if err != nil {
+			return mappings, xerrors.Errorf("pad%v: %w", i+1, err)
+		}
+		mappings[i] = m
+	}
+	if err := impl.ValidateKeyMappings(mappings[:]...); err != nil {
+		return mappings, xerrors.Errorf(": %w", err)
+	}
+	return mappings, nil
+}
+
+// loadGamepads ... 1P-4Pのゲームパッドを返す（使わない場合はnil）
+func loadGamepads(kc *keyConfig) ([players]*impl.GamepadPad, error) {
+	var gamepads [players]*impl.GamepadPad
+
+	profiles := map[string]impl.GamepadProfile{
+		standardGamepadProfile: impl.StandardGamepadProfile(),
+	}
+	for name, pc := range kc.GamepadProfiles {
+		p, err := pc.toProfile()
+		if err != nil {
+			return gamepads, xerrors.Errorf("gamepad profile %v: %w", name, err)
+		}
+		profiles[name] = p
+	}
+
+	configs := defaultGamepadConfigs
+	for i, gc := range []*gamepadConfig{kc.Gamepad1, kc.Gamepad2, kc.Gamepad3, kc.Gamepad4} {
+		if gc != nil {
+			configs[i] = *gc
+		}
+	}
+
+	used := map[int]int{}
+	for i, gc := range configs {
+		if gc.Device < 0 {
+			continue
+		}
+		if prev, ok := used[gc.Device]; ok {
+			return gamepads, xerrors.Errorf("gamepad device %v is assigned to both gamepad%v and gamepad%v", gc.Device, prev+1, i+1)
+		}
+		used[gc.Device] = i
+
+		name := gc.Profile
+		if name == "" {
+			name = standardGamepadProfile
+		}
+		p, ok := profiles[name]
+		if !ok {
+			return gamepads, xerrors.Errorf("gamepad%v: unknown gamepad profile: %v", i+1, name)
+		}
+		gamepads[i] = impl.NewGamepadPad(gc.Device, p)
+	}
+	return gamepads, nil
+}
+
+// loadFamilyKeyboardMapping ... ファミリーベーシックのキーボードの割り当てを返す
+func loadFamilyKeyboardMapping(kc *keyConfig) (impl.FamilyKeyboardMapping, error) {
+	names := impl.DefaultFamilyKeyboardNames()
+	for k, hostKeys := range kc.Keyboard {
+		key, err := domain.ParseKeyboardKey(k)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid key config: %w", err)
+		}
+		names[string(key)] = hostKeys
+	}
+
+	m, err := impl.ParseFamilyKeyboardMapping(names)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid key config: %w", err)
+	}
+	return m, nil
+}
+
+// loadPowerPadMapping ... Power Padの割り当てを返す
+func loadPowerPadMapping(kc *keyConfig, side domain.PowerPadSide) (impl.PowerPadMapping, error) {
+	names := impl.DefaultPowerPadKeyNames(side)
+	if kc.PowerPad != nil {
+		names = map[string][]string{}
+		for b, k := range kc.PowerPad {
+			names[b] = k
+		}
+	}
+
+	m, err := impl.ParsePowerPadMapping(side, names)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid key config: %w", err)
+	}
+	mappings, err := loadKeyMappings(kc)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid key config: %w", err)
+	}
+	if err := impl.ValidatePowerPadMapping(m, mappings[:]...); err != nil {
+		return nil, xerrors.Errorf("invalid key config: %w", err)
+	}
+	return m, nil
+}
+
+// readKeyConfig ... 知らないキーはエラー（pが空の場合はデフォルトの割り当て）
+func readKeyConfig(p string) (*keyConfig, error) {
+	if p == "" {
+		return &keyConfig{}, nil
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	defer f.Close()
+
+	kc := &keyConfig{}
+	d := json.NewDecoder(f)
+	d.DisallowUnknownFields()
+	if err := d.Decode(kc); err != nil {
+		return nil, xerrors.Errorf("failed to read key config: %v: %w", p, err)
+	}
+	return kc, nil
+}
+
+// makePads ... キーボードとゲームパッドを組み合わせた1P-4Pのパッドを返す
+// captureがnil以外の場合、ファミリーベーシックのキーボードを使っている間はキーボードでパッドを操作しない
+func makePads(kc *keyConfig, capture *impl.KeyboardCapture) ([players]domain.Pad, error) {
+	var pads [players]domain.Pad
+
+	mappings, err := loadKeyMappings(kc)
+	if err != nil {
+		return pads, xerrors.Errorf("invalid key config: %w", err)
+	}
+	gamepads, err := loadGamepads(kc)
+	if err != nil {
+		return pads, xerrors.Errorf("invalid key config: %w", err)
+	}
+
+	for i := range pads {
+		keyboard := impl.NewPad(mappings[i])
+		if capture != nil {
+			keyboard = capture.WrapPad(keyboard)
+		}
+		if gamepads[i] == nil {
+			pads[i] = keyboard
+			continue
+		}
+		pads[i] = domain.NewCombinedPad(keyboard, gamepads[i])
+	}
+	return pads, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"nes-go/pkg/domain"
+
+	"github.com/hajimehoshi/ebiten"
+)
+
+func TestKeyNamesUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    keyNames
+		wantErr bool
+	}{
+		{
+			name: "when key is a string, it is a single key",
+			json: `"X"`,
+			want: keyNames{"X"},
+		},
+		{
+			name: "when key is an array, all keys are used",
+			json: `["Enter", "Space"]`,
+			want: keyNames{"Enter", "Space"},
+		},
+		{
+			name:    "when key is a number, return error",
+			json:    `1`,
+			wantErr: true,
+		},
+		{
+			name:    "when array has a number, return error",
+			json:    `["Enter", 1]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got keyNames
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wrong error\nwantErr: %#v\ngot: %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadKeyMappings(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    [players][]ebiten.Key // プレイヤーごとのAボタンのキー
+		wantErr string                // エラーメッセージに含まれる文字列（空の場合はエラーにならない）
+	}{
+		{
+			name: "when nothing is specified, both players use default keys",
+			json: `{}`,
+			want: [players][]ebiten.Key{{ebiten.KeyA}, {ebiten.KeyH}},
+		},
+		{
+			name: "when pad2 is specified, only pad2 is replaced",
+			json: `{"pad2": {"A": ["Z", "X"], "B": "C"}}`,
+			want: [players][]ebiten.Key{{ebiten.KeyA}, {ebiten.KeyZ, ebiten.KeyX}},
+		},
+		{
+			name:    "when key is unknown, return error with the player",
+			json:    `{"pad2": {"A": "NoSuchKey"}}`,
+			wantErr: "pad2: button A: unknown key: NoSuchKey",
+		},
+		{
+			name:    "when button is unknown, return error",
+			json:    `{"pad1": {"TURBO": "X"}}`,
+			wantErr: "unknown button: TURBO",
+		},
+		{
+			name:    "when pad2 uses a default key of pad1, return error",
+			json:    `{"pad2": {"A": "A"}}`,
+			wantErr: "key A is assigned to both pad1 A and pad2 A",
+		},
+		{
+			name:    "when pad1 uses a hotkey, return error",
+			json:    `{"pad1": {"A": "F5"}}`,
+			wantErr: "key F5 is assigned to both hotkey save state and pad1 A",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := &keyConfig{}
+			if err := json.Unmarshal([]byte(tt.json), kc); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			mappings, err := loadKeyMappings(kc)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("wrong error\nwant: %#v\ngot: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to load: %v", err)
+			}
+			for i, want := range tt.want {
+				if got := mappings[i][domain.ButtonTypeA]; !reflect.DeepEqual(got, want) {
+					t.Errorf("wrong output\nplayer: %v\nwant: %#v\ngot: %#v", i+1, want, got)
+				}
+			}
+		})
+	}
+}
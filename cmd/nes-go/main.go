@@ -1,27 +1,75 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
 	"nes-go/pkg/domain"
 	"nes-go/pkg/impl"
 	"nes-go/pkg/log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/hajimehoshi/ebiten"
 	"golang.org/x/xerrors"
 )
 
-const (
-	LOGLEVEL           = log.LevelInfo
-	SCALE              = 2.5
-	ENABLE_DEBUG_PRINT = true
-	ENABLE_FUNC_NAME   = false
-	FIRST_PC           = 0x0000
-)
+const usage = `usage:
+  nes-go [run] [flags] {ROMファイル}  ROMを実行する
+  nes-go info {ROMファイル}           ROMの情報を表示する
+  nes-go help                         このヘルプと、runのフラグを表示する
+`
 
 func main() {
+	cmd, args := "run", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		switch args[0] {
+		case "run", "info", "help":
+			cmd, args = args[0], args[1:]
+		}
+	}
+
+	var err error
+	switch cmd {
+	case "info":
+		err = infoCommand(args)
+	case "help":
+		defaultConfig().newFlagSet("run").Usage()
+	default:
+		err = runCommand(args)
+	}
+
+	if err != nil {
+		if !xerrors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// runCommand ... ROMを実行する
+func runCommand(args []string) error {
+	c, rest, err := parseConfig("run", args)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if len(rest) < 1 {
+		return xerrors.Errorf("failed to start, rom is nil\n%v", usage)
+	}
+	romPath := rest[0]
+
+	level, err := c.getLogLevel()
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
 	log.SetOutput(os.Stdout)
-	log.SetLogLevel(LOGLEVEL)
-	log.SetEnableFuncName(ENABLE_FUNC_NAME)
+	log.SetLogLevel(level)
+	log.SetEnableFuncName(c.LogFuncName)
+	log.SetCategories(c.LogCategories)
 
 	log.Debug("========================================")
 	log.Debug("program start")
@@ -33,67 +81,320 @@ func main() {
 		log.Debug("========================================")
 	}()
 
-	if len(os.Args) < 2 {
-		panic(xerrors.Errorf("failed to start, rom is nil"))
+	log.Info("rom: %v", romPath)
+
+	if c.Palette != "" {
+		if err := loadPalette(c.Palette); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+	if c.GameDB != "" {
+		if err := loadGameDatabase(c.GameDB); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
 	}
 
-	romPath := os.Args[1]
+	firstPC, err := c.getFirstPC()
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	region, err := c.getRegion()
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	faultPolicy, err := c.getFaultPolicy()
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	frameHandoff, err := c.getFrameHandoffMode()
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	rewind, err := c.getRewindConfig()
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
 
-	log.Info("rom: %v", romPath)
+	multitap, err := c.getMultitap()
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
 
-	bus := impl.NewBus()
+	kc, err := readKeyConfig(c.Keys)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
 
-	var cpu domain.CPU
-	if FIRST_PC == 0 {
-		cpu = impl.NewCPU(nil)
-	} else {
-		firstPC := uint16(FIRST_PC)
-		cpu = impl.NewCPU(&firstPC)
+	// ファミリーベーシックのキーボードを使う場合は、ホストのキーボードをキーボードとパッド・ホットキーで切り替える
+	var capture *impl.KeyboardCapture
+	if c.usesFamilyKeyboard() {
+		capture = impl.NewKeyboardCapture(true)
 	}
 
-	ppu := impl.NewPPU2()
+	pads, err := makePads(kc, capture)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
 
-	renderer, err := impl.NewRenderer(
-		SCALE,
-		"nes-go",
-		ENABLE_DEBUG_PRINT,
-	)
+	ppu := impl.NewPPU2()
+	port2, err := makePort2(c, kc, ppu)
 	if err != nil {
-		return
+		return xerrors.Errorf(": %w", err)
+	}
+	expansion, expansionHook, err := makeExpansion(c, kc, capture)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	var renderer domain.Renderer
+	if c.Frames > 0 {
+		renderer = impl.NewHeadlessRenderer(c.Frames)
+	} else {
+		renderer, err = impl.NewRenderer(
+			c.Scale,
+			"nes-go",
+			c.DebugPrint,
+			frameHandoff,
+		)
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+		ebiten.SetFullscreen(c.Fullscreen)
 	}
 
 	nes := domain.NES{
-		Bus:      bus,
-		CPU:      cpu,
-		PPU:      ppu,
-		Pad1:     makePad1(),
-		Pad2:     makePad2(),
-		Renderer: renderer,
-		Recorder: &domain.Recorder{},
+		Bus:         impl.NewBus(),
+		CPU:         impl.NewCPU(firstPC),
+		PPU:         ppu,
+		Pad1:        pads[0],
+		Pad2:        pads[1],
+		Pad3:        pads[2],
+		Pad4:        pads[3],
+		Port2:       port2,
+		Multitap:    multitap,
+		Expansion:   expansion,
+		Renderer:    renderer,
+		Recorder:    &domain.Recorder{},
+		Rewind:      rewind,
+		Region:      region,
+		TurboRate:   c.TurboRate,
+		FaultPolicy: faultPolicy,
+	}
+
+	if c.Trace != "" {
+		w, closeTrace, err := openTrace(c.Trace)
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+		defer closeTrace()
+		nes.Trace = w
 	}
 
 	if err := nes.Setup(romPath); err != nil {
-		panic(err)
+		return xerrors.Errorf(": %w", err)
 	}
 
-	if err := nes.Run(); err != nil {
-		panic(err)
+	hotkeys := []func() error{
+		impl.NewStateSlotHotkeys(&nes).Update,
+		impl.NewRewindHotkey(&nes).Update,
+		impl.NewSpeedHotkeys(&nes).Update,
+		impl.NewResetHotkeys(&nes).Update,
+	}
+	for _, h := range hotkeys {
+		if capture != nil {
+			h = capture.Filter(h)
+		}
+		renderer.AddUpdateHook(h)
 	}
+	if capture != nil {
+		renderer.AddUpdateHook(capture.Update)
+	}
+	if expansionHook != nil {
+		renderer.AddUpdateHook(expansionHook)
+	}
+
+	if c.Frames > 0 {
+		if err := nes.SetSpeed(domain.SpeedUnthrottled); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+
+	if c.State != "" {
+		if err := loadState(&nes, c.State); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+	if c.Play != "" {
+		if err := playMovie(&nes, c.Play); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+	if c.Record != "" {
+		if err := nes.StartMovieRecording(c.State == ""); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+
+	ctx, cancel := newSignalContext()
+	defer cancel()
+
+	runErr := nes.Run(ctx)
+
+	if c.Record != "" {
+		if err := writeMovie(nes.StopMovie(), c.Record); err != nil {
+			log.Warn("%+v", err)
+		}
+	}
+
+	if runErr != nil {
+		return xerrors.Errorf(": %w", runErr)
+	}
+	return nil
+}
+
+// infoCommand ... ROMのヘッダとチェックサムを表示する
+func infoCommand(args []string) error {
+	if len(args) < 1 {
+		return xerrors.Errorf("rom is nil\n%v", usage)
+	}
+
+	rom, err := domain.FetchROM(args[0])
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	h := rom.Header
+	format := "iNES"
+	if h.IsNES20() {
+		format = "NES 2.0"
+	}
+	mirroring := "horizontal"
+	if (h.Flags6 & 0x01) == 0x01 {
+		mirroring = "vertical"
+	}
+
+	fmt.Printf("rom:       %v\n", args[0])
+	fmt.Printf("format:    %v\n", format)
+	fmt.Printf("mapper:    %v\n", (h.Flags7&0xF0)|(h.Flags6>>4))
+	fmt.Printf("prg-rom:   %vKB\n", int(h.PRGROMSize)*16)
+	fmt.Printf("chr-rom:   %vKB\n", int(h.CHRROMSize)*8)
+	fmt.Printf("mirroring: %v\n", mirroring)
+	fmt.Printf("battery:   %v\n", h.HasBattery())
+	fmt.Printf("region:    %v\n", rom.GetRegion())
+	fmt.Printf("crc32:     %08X\n", rom.CRC32)
+	fmt.Printf("md5:       %X\n", rom.MD5)
+	return nil
+}
+
+// loadPalette ...
+func loadPalette(p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	defer f.Close()
+
+	if err := domain.LoadSystemPalette(f); err != nil {
+		return xerrors.Errorf("failed to load palette: %v: %w", p, err)
+	}
+	return nil
+}
+
+// loadGameDatabase ...
+func loadGameDatabase(p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	defer f.Close()
+
+	if err := domain.LoadGameDatabase(f); err != nil {
+		return xerrors.Errorf("failed to load game database: %v: %w", p, err)
+	}
+	return nil
 }
 
-func makePad1() domain.Pad {
-	return impl.NewPad(map[domain.ButtonType]ebiten.Key{
-		domain.ButtonTypeA:      ebiten.KeyA,
-		domain.ButtonTypeB:      ebiten.KeyB,
-		domain.ButtonTypeSelect: ebiten.KeyShift,
-		domain.ButtonTypeStart:  ebiten.KeySpace,
-		domain.ButtonTypeUp:     ebiten.KeyUp,
-		domain.ButtonTypeDown:   ebiten.KeyDown,
-		domain.ButtonTypeLeft:   ebiten.KeyLeft,
-		domain.ButtonTypeRight:  ebiten.KeyRight,
-	})
+// loadState ...
+func loadState(nes *domain.NES, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	defer f.Close()
+
+	if err := nes.LoadState(f); err != nil {
+		return xerrors.Errorf("failed to load state: %v: %w", p, err)
+	}
+	return nil
+}
+
+// openTrace ... トレースの出力先を開き、書き出して閉じる関数と一緒に返す
+func openTrace(p string) (io.Writer, func(), error) {
+	if p == "-" {
+		w := bufio.NewWriter(os.Stdout)
+		return w, func() { w.Flush() }, nil
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return nil, nil, xerrors.Errorf(": %w", err)
+	}
+	w := bufio.NewWriter(f)
+	return w, func() {
+		if err := w.Flush(); err != nil {
+			log.Warn("failed to write trace: %v", err)
+		}
+		f.Close()
+	}, nil
 }
 
-func makePad2() domain.Pad {
-	return impl.NewPad(map[domain.ButtonType]ebiten.Key{})
+// newSignalContext ... SIGINT/SIGTERMでキャンセルされるcontext
+func newSignalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(sig)
+	}()
+	return ctx, cancel
+}
+
+func playMovie(nes *domain.NES, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	defer f.Close()
+
+	m, err := domain.ReadFM2(f)
+	if err != nil {
+		return xerrors.Errorf("failed to read movie: %w", err)
+	}
+	if err := nes.PlayMovie(m); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+func writeMovie(m *domain.Movie, p string) error {
+	if m == nil {
+		return nil
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	defer f.Close()
+
+	if err := m.WriteFM2(f); err != nil {
+		return xerrors.Errorf("failed to write movie: %w", err)
+	}
+	log.Info("movie saved: %v (frames: %v)", p, len(m.Frames))
+	return nil
 }
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// makePort2 ... 2Pのポートに接続する機器（空またはpadの場合はnilで、標準コントローラーを接続する）
+func makePort2(c *config, kc *keyConfig, ppu domain.PPU) (domain.PortDevice, error) {
+	switch strings.ToLower(c.Port2) {
+	case "", "pad":
+		return nil, nil
+	case "zapper":
+		return domain.NewZapper(ppu, impl.NewMouseZapperInput()), nil
+	case "vaus":
+		return domain.NewVaus(makeVausInput(c)), nil
+	case "powerpad":
+		side, err := domain.ParsePowerPadSide(c.PowerPadSide)
+		if err != nil {
+			return nil, xerrors.Errorf(": %w", err)
+		}
+		m, err := loadPowerPadMapping(kc, side)
+		if err != nil {
+			return nil, xerrors.Errorf(": %w", err)
+		}
+		return domain.NewPowerPad(impl.NewKeyboardPowerPadInput(m), side), nil
+	default:
+		return nil, xerrors.Errorf("unknown port2 device: %v", c.Port2)
+	}
+}
+
+// makeExpansion ... ファミコンの拡張端子に接続する機器（空またはnoneの場合はnil）と、Renderer.AddUpdateHookに登録する関数（不要な場合はnil）
+func makeExpansion(c *config, kc *keyConfig, capture *impl.KeyboardCapture) (domain.ExpansionDevice, func() error, error) {
+	switch strings.ToLower(c.Expansion) {
+	case "", "none":
+		return nil, nil, nil
+	case "vaus":
+		return domain.NewFamicomVaus(makeVausInput(c)), nil, nil
+	case "keyboard":
+		textMode, err := c.isKeyboardTextMode()
+		if err != nil {
+			return nil, nil, xerrors.Errorf(": %w", err)
+		}
+		m, err := loadFamilyKeyboardMapping(kc)
+		if err != nil {
+			return nil, nil, xerrors.Errorf(": %w", err)
+		}
+		input := impl.NewFamilyKeyboardInput(m, capture, textMode)
+		return domain.NewFamilyBasicKeyboard(input), input.Update, nil
+	default:
+		return nil, nil, xerrors.Errorf("unknown expansion device: %v", c.Expansion)
+	}
+}
+
+// makeVausInput ... paddle-gamepadが負の場合はマウス、それ以外はゲームパッドの左スティックの横軸とAボタンで操作する
+func makeVausInput(c *config) domain.VausInput {
+	if c.PaddleGamepad < 0 {
+		return impl.NewMouseVausInput()
+	}
+	fire := impl.StandardGamepadProfile().Buttons[domain.ButtonTypeA][0]
+	return impl.NewGamepadVausInput(c.PaddleGamepad, 0, fire)
+}
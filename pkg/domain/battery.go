@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"io/ioutil"
+	"os"
+
+	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
+)
+
+// loadBatteryRAM ... バッテリーバックアップされたRAMをファイルから読み込む（ファイルがない場合は何もしない）
+func loadBatteryRAM(p string, ram []byte) error {
+	data, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	if len(data) != len(ram) {
+		log.Warn("battery save size mismatch; path: %v, want: %v, got: %v", p, len(ram), len(data))
+	}
+	copy(ram, data)
+	return nil
+}
+
+// saveBatteryRAM ... バッテリーバックアップされたRAMをファイルに書き出す
+// 書き込み途中で終了しても既存のファイルが壊れないように、一時ファイルに書き込んでから置き換える
+func saveBatteryRAM(p string, ram []byte) error {
+	tmp := p + ".tmp"
+	if err := ioutil.WriteFile(tmp, ram, 0644); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestBatteryRAM(t *testing.T) {
+	dir, err := ioutil.TempDir("", "battery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name  string
+		saved []byte // nilの場合はファイルを作らない
+		want  []byte
+	}{
+		{
+			name:  "when file does not exist, RAM is not changed",
+			saved: nil,
+			want:  []byte{0x12, 0x34, 0x56, 0x78},
+		},
+		{
+			name:  "when file is saved, RAM is loaded",
+			saved: []byte{0x01, 0x02, 0x03, 0x04},
+			want:  []byte{0x01, 0x02, 0x03, 0x04},
+		},
+		{
+			name:  "when file is shorter than RAM, the rest is not changed",
+			saved: []byte{0x01, 0x02},
+			want:  []byte{0x01, 0x02, 0x56, 0x78},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := filepath.Join(dir, strconv.Itoa(i)+".sav")
+			if tt.saved != nil {
+				if err := saveBatteryRAM(p, tt.saved); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ram := []byte{0x12, 0x34, 0x56, 0x78}
+			if err := loadBatteryRAM(p, ram); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(ram, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, ram)
+			}
+		})
+	}
+}
@@ -9,6 +9,8 @@ const (
 	SpriteWidth = 8
 	// SpriteHeight ... スプライトサイズ（横）
 	SpriteHeight = 8
+	// LargeSpriteHeight ... 8x16モードのスプライトサイズ（縦）
+	LargeSpriteHeight = 16
 
 	// TileWidth ... タイルサイズ（横）
 	TileWidth = 8
@@ -175,6 +177,8 @@ const (
 	SLO Mnemonic = "SLO"
 	// ANC ...
 	ANC Mnemonic = "ANC"
+	// ALR ...
+	ALR Mnemonic = "ALR"
 	// RLA ...
 	RLA Mnemonic = "RLA"
 	// SRE ...
@@ -209,6 +213,16 @@ const (
 	ISB Mnemonic = "ISB"
 )
 
+// CPUVariant ... CPUの種類
+type CPUVariant int
+
+const (
+	// CPUVariant2A03 ... NES(ファミコン)のCPU（Dフラグを無視する）
+	CPUVariant2A03 CPUVariant = iota
+	// CPUVariantNMOS6502 ... NMOS 6502（Dフラグが立っているとADC/SBCがBCD演算になる）
+	CPUVariantNMOS6502
+)
+
 // AddressingMode ...
 // http://pgate1.at-ninja.jp/NES_on_FPGA/nes_cpu.htm
 type AddressingMode string
@@ -263,7 +277,7 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0x08: OpcodeProp{PHP, Implied, 3, true},
 	0x09: OpcodeProp{ORA, Immediate, 2, true},
 	0x0A: OpcodeProp{ASL, Accumulator, 2, true},
-	0x0B: OpcodeProp{ANC, Implied, 2, false},
+	0x0B: OpcodeProp{ANC, Immediate, 2, false},
 	0x0C: OpcodeProp{NOP, Absolute, 4, false},
 	0x0D: OpcodeProp{ORA, Absolute, 4, true},
 	0x0E: OpcodeProp{ASL, Absolute, 6, true},
@@ -295,7 +309,7 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0x28: OpcodeProp{PLP, Implied, 4, true},
 	0x29: OpcodeProp{AND, Immediate, 2, true},
 	0x2A: OpcodeProp{ROL, Accumulator, 2, true},
-	0x2B: OpcodeProp{ANC, Immediate, 4, false},
+	0x2B: OpcodeProp{ANC, Immediate, 2, false},
 	0x2C: OpcodeProp{BIT, Absolute, 4, true},
 	0x2D: OpcodeProp{AND, Absolute, 4, true},
 	0x2E: OpcodeProp{ROL, Absolute, 6, true},
@@ -327,7 +341,7 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0x48: OpcodeProp{PHA, Implied, 3, true},
 	0x49: OpcodeProp{EOR, Immediate, 2, true},
 	0x4A: OpcodeProp{LSR, Accumulator, 2, true},
-	0x4B: OpcodeProp{LSR, Immediate, 5, false},
+	0x4B: OpcodeProp{ALR, Immediate, 2, false},
 	0x4C: OpcodeProp{JMP, Absolute, 3, true},
 	0x4D: OpcodeProp{EOR, Absolute, 4, true},
 	0x4E: OpcodeProp{LSR, Absolute, 6, true},
@@ -359,7 +373,7 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0x68: OpcodeProp{PLA, Implied, 4, true},
 	0x69: OpcodeProp{ADC, Immediate, 2, true},
 	0x6A: OpcodeProp{ROR, Accumulator, 2, true},
-	0x6B: OpcodeProp{ARR, Immediate, 4, false},
+	0x6B: OpcodeProp{ARR, Immediate, 2, false},
 	0x6C: OpcodeProp{JMP, AbsoluteIndirect, 5, true},
 	0x6D: OpcodeProp{ADC, Absolute, 4, true},
 	0x6E: OpcodeProp{ROR, Absolute, 6, true},
@@ -391,7 +405,7 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0x88: OpcodeProp{DEY, Implied, 2, true},
 	0x89: OpcodeProp{NOP, Immediate, 2, false},
 	0x8A: OpcodeProp{TXA, Implied, 2, true},
-	0x8B: OpcodeProp{XAA, Immediate, 4, false},
+	0x8B: OpcodeProp{XAA, Immediate, 2, false},
 	0x8C: OpcodeProp{STY, Absolute, 4, true},
 	0x8D: OpcodeProp{STA, Absolute, 4, true},
 	0x8E: OpcodeProp{STX, Absolute, 4, true},
@@ -399,7 +413,7 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0x90: OpcodeProp{BCC, Relative, 2, true},
 	0x91: OpcodeProp{STA, IndirectIndexed, 6, true},
 	0x92: OpcodeProp{STP, Implied, 4, false},
-	0x93: OpcodeProp{AHX, IndirectIndexed, 4, false},
+	0x93: OpcodeProp{AHX, IndirectIndexed, 6, false},
 	0x94: OpcodeProp{STY, IndexedZeroPageX, 4, true},
 	0x95: OpcodeProp{STA, IndexedZeroPageX, 4, true},
 	0x96: OpcodeProp{STX, IndexedZeroPageY, 4, true},
@@ -407,11 +421,11 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0x98: OpcodeProp{TYA, Implied, 2, true},
 	0x99: OpcodeProp{STA, IndexedAbsoluteY, 5, true},
 	0x9A: OpcodeProp{TXS, Implied, 2, true},
-	0x9B: OpcodeProp{TAS, IndexedAbsoluteY, 4, false},
-	0x9C: OpcodeProp{SHY, IndexedAbsoluteX, 4, false},
+	0x9B: OpcodeProp{TAS, IndexedAbsoluteY, 5, false},
+	0x9C: OpcodeProp{SHY, IndexedAbsoluteX, 5, false},
 	0x9D: OpcodeProp{STA, IndexedAbsoluteX, 5, true},
-	0x9E: OpcodeProp{SHX, IndexedAbsoluteY, 4, false},
-	0x9F: OpcodeProp{AHX, IndexedAbsoluteY, 4, false},
+	0x9E: OpcodeProp{SHX, IndexedAbsoluteY, 5, false},
+	0x9F: OpcodeProp{AHX, IndexedAbsoluteY, 5, false},
 	0xA0: OpcodeProp{LDY, Immediate, 2, true},
 	0xA1: OpcodeProp{LDA, IndexedIndirect, 6, true},
 	0xA2: OpcodeProp{LDX, Immediate, 2, true},
@@ -423,7 +437,7 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0xA8: OpcodeProp{TAY, Implied, 2, true},
 	0xA9: OpcodeProp{LDA, Immediate, 2, true},
 	0xAA: OpcodeProp{TAX, Implied, 2, true},
-	0xAB: OpcodeProp{LAX, Immediate, 4, false},
+	0xAB: OpcodeProp{LAX, Immediate, 2, false},
 	0xAC: OpcodeProp{LDY, Absolute, 4, true},
 	0xAD: OpcodeProp{LDA, Absolute, 4, true},
 	0xAE: OpcodeProp{LDX, Absolute, 4, true},
@@ -455,7 +469,7 @@ var OpcodeProps = map[Opcode]OpcodeProp{
 	0xC8: OpcodeProp{INY, Implied, 2, true},
 	0xC9: OpcodeProp{CMP, Immediate, 2, true},
 	0xCA: OpcodeProp{DEX, Implied, 2, true},
-	0xCB: OpcodeProp{AXS, Immediate, 4, false},
+	0xCB: OpcodeProp{AXS, Immediate, 2, false},
 	0xCC: OpcodeProp{CPY, Absolute, 4, true},
 	0xCD: OpcodeProp{CMP, Absolute, 4, true},
 	0xCE: OpcodeProp{DEC, Absolute, 6, true},
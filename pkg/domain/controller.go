@@ -0,0 +1,87 @@
+package domain
+
+import "golang.org/x/xerrors"
+
+const (
+	// PortDataMask ... $4016/$4017の読み込みでポートの機器が返すビット（D0-D4）、上位ビットはオープンバス
+	PortDataMask = 0x1F
+	// PortStrobe ... $4016への書き込みのストローブ（OUT0）
+	PortStrobe = 0x01
+)
+
+// StandardController ... 標準コントローラー（8bitのシフトレジスタ）
+// ストローブが1の間はボタンの状態を読み込み続け、0になると1回読むごとに1bitずつ送り出す
+// A, B, SELECT, START, UP, DOWN, LEFT, RIGHTの順に読み、9回目以降は1を返す
+// 仕様: https://wiki.nesdev.com/w/index.php/Standard_controller
+type StandardController struct {
+	pad    Pad
+	strobe bool
+	shift  byte
+}
+
+// NewStandardController ...
+func NewStandardController(pad Pad) *StandardController {
+	return &StandardController{
+		pad: pad,
+	}
+}
+
+// latch ... ボタンの状態をシフトレジスタに読み込む（PadStateのビットは読み出し順に並んでいる）
+func (c *StandardController) latch() {
+	c.shift = byte(latchPadState(c.pad))
+}
+
+// Strobe ... ストローブが1の間と、1から0になったときにボタンの状態を読み込む
+func (c *StandardController) Strobe(out byte) {
+	prev := c.strobe
+	c.strobe = out&PortStrobe == PortStrobe
+	if c.strobe || prev {
+		c.latch()
+	}
+}
+
+// Read ...
+func (c *StandardController) Read() byte {
+	if c.strobe {
+		c.latch()
+		return c.shift & 0x01
+	}
+
+	d := c.shift & 0x01
+	c.shift = (c.shift >> 1) | 0x80
+	return d
+}
+
+// Peek ... シフトレジスタを進めずに次に読む値を返す
+func (c *StandardController) Peek() byte {
+	if c.strobe {
+		return byte(NewPadState(c.pad)) & 0x01
+	}
+	return c.shift & 0x01
+}
+
+// standardControllerState ... セーブステート用の状態
+type standardControllerState struct {
+	Strobe bool
+	Shift  byte
+}
+
+// MarshalState ...
+func (c *StandardController) MarshalState() ([]byte, error) {
+	data, err := EncodeState(standardControllerState{Strobe: c.strobe, Shift: c.shift})
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (c *StandardController) UnmarshalState(data []byte) error {
+	s := standardControllerState{}
+	if err := DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	c.strobe = s.Strobe
+	c.shift = s.Shift
+	return nil
+}
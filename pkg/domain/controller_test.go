@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStandardController(t *testing.T) {
+	// A, START, RIGHTを押している
+	pressed := map[ButtonType]bool{ButtonTypeA: true, ButtonTypeStart: true, ButtonTypeRight: true}
+
+	type op struct {
+		strobe *byte // nilの場合は読み込み
+	}
+	strobe := func(b byte) op { return op{strobe: &b} }
+	read := op{}
+
+	tests := []struct {
+		name string
+		ops  []op
+		want []byte
+	}{
+		{
+			name: "when strobe goes 1 then 0, read buttons in order and return 1 after 8 reads",
+			ops:  []op{strobe(1), strobe(0), read, read, read, read, read, read, read, read, read, read},
+			want: []byte{1, 0, 0, 1, 0, 0, 0, 1, 1, 1},
+		},
+		{
+			name: "when strobe is high, always return A",
+			ops:  []op{strobe(1), read, read, read},
+			want: []byte{1, 1, 1},
+		},
+		{
+			name: "when strobe is written again in the middle, reload buttons",
+			ops:  []op{strobe(1), strobe(0), read, read, strobe(1), strobe(0), read, read},
+			want: []byte{1, 0, 1, 0},
+		},
+		{
+			name: "when only OUT1 is written, it is not a strobe",
+			ops:  []op{strobe(1), strobe(0), read, strobe(0x02), read},
+			want: []byte{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewStandardController(&stubPad{pressed: pressed})
+			got := []byte{}
+			for _, o := range tt.ops {
+				if o.strobe != nil {
+					c.Strobe(*o.strobe)
+					continue
+				}
+				peek := c.Peek()
+				d := c.Read()
+				if peek != d {
+					t.Fatalf("wrong peek\nwant: %#v\ngot: %#v", d, peek)
+				}
+				got = append(got, d)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStandardControllerLatchOnFallingStrobe(t *testing.T) {
+	pad := &stubPad{pressed: map[ButtonType]bool{ButtonTypeA: true}}
+	c := NewStandardController(pad)
+
+	// ストローブが1の間に押したボタンは、0になったときに読み込まれる
+	c.Strobe(1)
+	pad.pressed = map[ButtonType]bool{ButtonTypeB: true}
+	c.Strobe(0)
+
+	got := []byte{c.Read(), c.Read()}
+	want := []byte{0, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+	}
+}
+
+func TestStandardControllerState(t *testing.T) {
+	pressed := map[ButtonType]bool{ButtonTypeB: true}
+	c := NewStandardController(&stubPad{pressed: pressed})
+	c.Strobe(1)
+	c.Strobe(0)
+	c.Read()
+
+	data, err := c.MarshalState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewStandardController(&stubPad{})
+	if err := restored.UnmarshalState(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.Read(); got != 1 {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", 1, got)
+	}
+}
@@ -0,0 +1,146 @@
+package domain
+
+import "golang.org/x/xerrors"
+
+// expansionDataMask ... 拡張端子の機器が返すビット（D1-D4）
+const expansionDataMask = PortDataMask &^ 0x01
+
+// FamicomExpansionPort ... 本体のコントローラー（D0）と拡張端子の機器（D1-D4）を同じアドレスから読むポート
+// 拡張端子の機器は両方のポートで共有するので、ストローブとセーブステートは$4016（port 0）のポートだけが扱う
+type FamicomExpansionPort struct {
+	main      *StandardController
+	expansion ExpansionDevice
+	port      int
+}
+
+// NewFamicomExpansionPort ...
+func NewFamicomExpansionPort(main Pad, expansion ExpansionDevice, port int) *FamicomExpansionPort {
+	return &FamicomExpansionPort{
+		main:      NewStandardController(main),
+		expansion: expansion,
+		port:      port,
+	}
+}
+
+// Strobe ...
+func (f *FamicomExpansionPort) Strobe(out byte) {
+	f.main.Strobe(out)
+	if f.port == 0 {
+		f.expansion.Strobe(out)
+	}
+}
+
+// Read ...
+func (f *FamicomExpansionPort) Read() byte {
+	return f.main.Read() | f.expansion.Read(f.port)&expansionDataMask
+}
+
+// Peek ...
+func (f *FamicomExpansionPort) Peek() byte {
+	return f.main.Peek() | f.expansion.Peek(f.port)&expansionDataMask
+}
+
+// famicomExpansionPortState ... セーブステート用の状態
+type famicomExpansionPortState struct {
+	Main      []byte
+	Expansion []byte // port 0のみ
+}
+
+// MarshalState ...
+func (f *FamicomExpansionPort) MarshalState() ([]byte, error) {
+	s := famicomExpansionPortState{}
+	var err error
+	if s.Main, err = f.main.MarshalState(); err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	if f.port == 0 {
+		if s.Expansion, err = f.expansion.MarshalState(); err != nil {
+			return nil, xerrors.Errorf(": %w", err)
+		}
+	}
+
+	data, err := EncodeState(s)
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (f *FamicomExpansionPort) UnmarshalState(data []byte) error {
+	s := famicomExpansionPortState{}
+	if err := DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if err := f.main.UnmarshalState(s.Main); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if f.port == 0 {
+		if err := f.expansion.UnmarshalState(s.Expansion); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+	return nil
+}
+
+// ExpansionControllers ... 拡張端子に接続する3P・4Pのコントローラー（$4016・$4017のD1）
+// ほとんどのゲームはD0とD1のORをとるので、拡張端子のコントローラーで1P・2Pを操作することもできる
+type ExpansionControllers struct {
+	controllers [2]*StandardController
+}
+
+// NewExpansionControllers ...
+func NewExpansionControllers(pad3, pad4 Pad) *ExpansionControllers {
+	return &ExpansionControllers{
+		controllers: [2]*StandardController{NewStandardController(pad3), NewStandardController(pad4)},
+	}
+}
+
+// Strobe ...
+func (e *ExpansionControllers) Strobe(out byte) {
+	for _, c := range e.controllers {
+		c.Strobe(out)
+	}
+}
+
+// Read ...
+func (e *ExpansionControllers) Read(port int) byte {
+	return e.controllers[port].Read() << 1
+}
+
+// Peek ...
+func (e *ExpansionControllers) Peek(port int) byte {
+	return e.controllers[port].Peek() << 1
+}
+
+// MarshalState ...
+func (e *ExpansionControllers) MarshalState() ([]byte, error) {
+	states := [2][]byte{}
+	for i, c := range e.controllers {
+		data, err := c.MarshalState()
+		if err != nil {
+			return nil, xerrors.Errorf(": %w", err)
+		}
+		states[i] = data
+	}
+
+	data, err := EncodeState(states)
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (e *ExpansionControllers) UnmarshalState(data []byte) error {
+	states := [2][]byte{}
+	if err := DecodeState(data, &states); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	for i, c := range e.controllers {
+		if err := c.UnmarshalState(states[i]); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// KeyboardKey ... ファミリーベーシックのキーボードのキー（キートップの表記）
+type KeyboardKey string
+
+const (
+	KeyboardKeyReturn    KeyboardKey = KeyboardKey("RETURN")
+	KeyboardKeySpace     KeyboardKey = KeyboardKey("SPACE")
+	KeyboardKeyLeftShift KeyboardKey = KeyboardKey("LSHIFT")
+)
+
+const (
+	// keyboardRows ... キーマトリクスの行数
+	keyboardRows = 9
+	// keyboardNoKeys ... キーを押していない場合に$4017から読む値（押しているキーのビットが0になる）
+	keyboardNoKeys = 0x1E
+
+	// keyboardReset ... $4016への書き込みで最初の行に戻すビット
+	keyboardReset = 0x01
+	// keyboardColumn ... $4016への書き込みで列を選択するビット（1から0になると次の行に進む）
+	keyboardColumn = 0x02
+	// keyboardEnable ... $4016への書き込みでキーマトリクスを有効にするビット
+	keyboardEnable = 0x04
+)
+
+// keyboardMatrix ... [行][列][$4017のD1-D4]のキー
+// 仕様: https://wiki.nesdev.com/w/index.php/Family_BASIC_Keyboard
+var keyboardMatrix = [keyboardRows][2][4]KeyboardKey{
+	{{"]", "[", KeyboardKeyReturn, "F8"}, {"STOP", "¥", "RSHIFT", "KANA"}},
+	{{";", ":", "@", "F7"}, {"^", "-", "/", "_"}},
+	{{"K", "L", "O", "F6"}, {"0", "P", ",", "."}},
+	{{"J", "U", "I", "F5"}, {"8", "9", "N", "M"}},
+	{{"H", "G", "Y", "F4"}, {"6", "7", "V", "B"}},
+	{{"D", "R", "T", "F3"}, {"4", "5", "C", "F"}},
+	{{"A", "S", "W", "F2"}, {"3", "E", "Z", "X"}},
+	{{"CTR", "Q", "ESC", "F1"}, {"2", "1", "GRPH", KeyboardKeyLeftShift}},
+	{{"LEFT", "RIGHT", "UP", "CLR"}, {"INS", "DEL", KeyboardKeySpace, "DOWN"}},
+}
+
+// KeyboardKeyList ... キーボードのすべてのキー（キーマトリクスの順）
+func KeyboardKeyList() []KeyboardKey {
+	keys := []KeyboardKey{}
+	for _, row := range keyboardMatrix {
+		for _, column := range row {
+			keys = append(keys, column[:]...)
+		}
+	}
+	return keys
+}
+
+// ParseKeyboardKey ... キーの名前（大文字小文字は区別しない）からKeyboardKeyを取得
+func ParseKeyboardKey(name string) (KeyboardKey, error) {
+	for _, k := range KeyboardKeyList() {
+		if strings.EqualFold(name, string(k)) {
+			return k, nil
+		}
+	}
+	return "", xerrors.Errorf("unknown keyboard key: %v", name)
+}
+
+// FamilyBasicKeyboard ... ファミリーベーシックのキーボード（拡張端子に接続する）
+// $4016への書き込みで行・列を選択し、$4017のD1-D4から選択した4つのキーを読む（押している場合は0）
+type FamilyBasicKeyboard struct {
+	input   KeyboardInput
+	row     int
+	column  int
+	enabled bool
+}
+
+// NewFamilyBasicKeyboard ...
+func NewFamilyBasicKeyboard(input KeyboardInput) *FamilyBasicKeyboard {
+	return &FamilyBasicKeyboard{
+		input: input,
+	}
+}
+
+// Load ...
+func (k *FamilyBasicKeyboard) Load() error {
+	return k.input.Load()
+}
+
+// Strobe ... 列が1から0になると次の行に進み、9行目の次は最初の行に戻る
+func (k *FamilyBasicKeyboard) Strobe(out byte) {
+	prev := k.column
+	k.column = int(out&keyboardColumn) >> 1
+	k.enabled = out&keyboardEnable == keyboardEnable
+	if !k.enabled {
+		return
+	}
+	if prev == 1 && k.column == 0 {
+		k.row = (k.row + 1) % (keyboardRows + 1)
+	}
+	if out&keyboardReset == keyboardReset {
+		k.row = 0
+	}
+}
+
+// Read ... $4016からは何も読めない
+// キーマトリクスが無効な場合は0、9行目の次（キーボードの検出に使う）はどのキーも押していない値を返す
+func (k *FamilyBasicKeyboard) Read(port int) byte {
+	if port == 0 || !k.enabled {
+		return 0
+	}
+	if k.row >= keyboardRows {
+		return keyboardNoKeys
+	}
+
+	d := byte(keyboardNoKeys)
+	for i, key := range keyboardMatrix[k.row][k.column] {
+		if k.input.IsKeyPressed(key) {
+			d = d &^ (1 << uint(i+1))
+		}
+	}
+	return d
+}
+
+// Peek ... Readは状態を変えない
+func (k *FamilyBasicKeyboard) Peek(port int) byte {
+	return k.Read(port)
+}
+
+// familyBasicKeyboardState ... セーブステート用の状態
+type familyBasicKeyboardState struct {
+	Row     int
+	Column  int
+	Enabled bool
+}
+
+// MarshalState ...
+func (k *FamilyBasicKeyboard) MarshalState() ([]byte, error) {
+	data, err := EncodeState(familyBasicKeyboardState{Row: k.row, Column: k.column, Enabled: k.enabled})
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (k *FamilyBasicKeyboard) UnmarshalState(data []byte) error {
+	s := familyBasicKeyboardState{}
+	if err := DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	k.row = s.Row
+	k.column = s.Column
+	k.enabled = s.Enabled
+	return nil
+}
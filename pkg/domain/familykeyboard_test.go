@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// stubKeyboardInput ... 指定したキーだけ押されているキーボード
+type stubKeyboardInput struct {
+	pressed map[KeyboardKey]bool
+}
+
+func (i *stubKeyboardInput) Load() error {
+	return nil
+}
+
+func (i *stubKeyboardInput) IsKeyPressed(k KeyboardKey) bool {
+	return i.pressed[k]
+}
+
+func TestFamilyBasicKeyboard(t *testing.T) {
+	// 0行目の列0のRETURN（D3）と、7行目の列1のLSHIFT（D4）を押している
+	input := &stubKeyboardInput{pressed: map[KeyboardKey]bool{KeyboardKeyReturn: true, KeyboardKeyLeftShift: true}}
+
+	// 最初の行から列0・列1を交互に選び、すべての行を読む
+	k := NewFamilyBasicKeyboard(input)
+	k.Strobe(keyboardEnable | keyboardReset)
+	got := []byte{}
+	for row := 0; row <= keyboardRows; row++ {
+		k.Strobe(keyboardEnable)
+		got = append(got, k.Read(1))
+		k.Strobe(keyboardEnable | keyboardColumn)
+		got = append(got, k.Read(1))
+	}
+
+	want := []byte{
+		0x16, 0x1E, // 0行目
+		0x1E, 0x1E,
+		0x1E, 0x1E,
+		0x1E, 0x1E,
+		0x1E, 0x1E,
+		0x1E, 0x1E,
+		0x1E, 0x1E,
+		0x1E, 0x0E, // 7行目
+		0x1E, 0x1E,
+		0x1E, 0x1E, // 9行目の次
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+	}
+
+	tests := []struct {
+		name string
+		out  byte
+		port int
+		want byte
+	}{
+		{
+			name: "when the matrix is disabled, return 0",
+			out:  keyboardReset,
+			port: 1,
+			want: 0x00,
+		},
+		{
+			name: "when reading $4016, return 0",
+			out:  keyboardEnable | keyboardReset,
+			port: 0,
+			want: 0x00,
+		},
+		{
+			name: "when reset, return to the first row",
+			out:  keyboardEnable | keyboardReset,
+			port: 1,
+			want: 0x16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k.Strobe(tt.out)
+			got := k.Read(tt.port)
+			if got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestKeyboardTyper(t *testing.T) {
+	typer := NewKeyboardTyper()
+	typer.Type("a!\t")
+
+	got := [][]KeyboardKey{}
+	for typer.IsTyping() {
+		got = append(got, typer.Step())
+	}
+
+	a := []KeyboardKey{"A"}
+	shift1 := []KeyboardKey{KeyboardKeyLeftShift, "1"}
+	want := [][]KeyboardKey{
+		a, a, a, nil, nil, nil,
+		shift1, shift1, shift1, nil, nil, nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+	}
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// ErrUnsupportedOpcode ... CPUが実行できないオペコード
+	ErrUnsupportedOpcode = xerrors.New("unsupported opcode")
+	// ErrCPUJammed ... CPUが停止した（STP命令）
+	ErrCPUJammed = xerrors.New("cpu jammed")
+)
+
+// FaultPolicy ... CPUが停止したり、実行できないオペコードに遭遇したときの動作
+type FaultPolicy int
+
+const (
+	// FaultPolicyHalt ... エミュレーションを終了し、NES.Runがエラーを返す
+	FaultPolicyHalt FaultPolicy = iota
+	// FaultPolicyReset ... リセットして実行を続ける
+	FaultPolicyReset
+	// FaultPolicyBreak ... 一時停止する（Resume/AdvanceFrameで再開できる）
+	FaultPolicyBreak
+)
+
+// String ...
+func (p FaultPolicy) String() string {
+	switch p {
+	case FaultPolicyHalt:
+		return "halt"
+	case FaultPolicyReset:
+		return "reset"
+	case FaultPolicyBreak:
+		return "break"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseFaultPolicy ... halt/reset/breakからFaultPolicyを取得
+func ParseFaultPolicy(s string) (FaultPolicy, error) {
+	for _, p := range []FaultPolicy{FaultPolicyHalt, FaultPolicyReset, FaultPolicyBreak} {
+		if strings.EqualFold(s, p.String()) {
+			return p, nil
+		}
+	}
+	return FaultPolicyHalt, xerrors.Errorf("unknown fault policy: %v", s)
+}
+
+// IsFault ... FaultPolicyの対象となるエラーか
+func IsFault(err error) bool {
+	return xerrors.Is(err, ErrUnsupportedOpcode) || xerrors.Is(err, ErrCPUJammed)
+}
@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// FCEUXのムービー形式（テキスト形式のみ対応）
+// 仕様: http://fceux.com/web/help/fm2.html
+
+const (
+	fm2Version       = 3
+	fm2Base64Prefix  = "base64:"
+	fm2InputNone     = 0
+	fm2InputGamepad  = 1
+	fm2GamepadLength = 8
+)
+
+// ReadFM2 ...
+func ReadFM2(r io.Reader) (*Movie, error) {
+	m := &Movie{
+		Comments: []string{},
+		Frames:   []MovieFrame{},
+	}
+	ports := [2]int{fm2InputGamepad, fm2InputGamepad}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "|") {
+			f, err := parseFM2Frame(text, ports, m.FourScore)
+			if err != nil {
+				return nil, xerrors.Errorf("line %v: %w", line, err)
+			}
+			m.Frames = append(m.Frames, f)
+			continue
+		}
+
+		kv := strings.SplitN(text, " ", 2)
+		key := kv[0]
+		value := ""
+		if len(kv) == 2 {
+			value = kv[1]
+		}
+
+		var err error
+		switch key {
+		case "version":
+			if value != strconv.Itoa(fm2Version) {
+				err = xerrors.Errorf("unsupported version: %v", value)
+			}
+		case "binary":
+			if value != "0" {
+				err = xerrors.New("binary input log is not supported")
+			}
+		case "rerecordCount":
+			m.RerecordCount, err = strconv.Atoi(value)
+		case "palFlag":
+			m.PAL = value == "1"
+		case "romFilename":
+			m.ROMFilename = value
+		case "romChecksum":
+			var b []byte
+			b, err = decodeFM2Base64(value)
+			if err == nil {
+				if len(b) != len(m.ROMChecksum) {
+					err = xerrors.Errorf("wrong checksum length: %v", len(b))
+				} else {
+					copy(m.ROMChecksum[:], b)
+				}
+			}
+		case "guid":
+			m.GUID = value
+		case "comment":
+			m.Comments = append(m.Comments, value)
+		case "savestate":
+			m.SaveState, err = decodeFM2Base64(value)
+		case "fourscore":
+			m.FourScore = value == "1"
+		case "port0", "port1":
+			idx := int(key[4] - '0')
+			ports[idx], err = strconv.Atoi(value)
+			if err == nil && ports[idx] != fm2InputNone && ports[idx] != fm2InputGamepad {
+				err = xerrors.Errorf("unsupported input device: %v", value)
+			}
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("line %v(%v): %w", line, key, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+
+	return m, nil
+}
+
+// parseFM2Frame ... |commands|port0|port1|port2|
+// Four Scoreの場合は |commands|pad1|pad2|pad3|pad4|port2|
+func parseFM2Frame(text string, ports [2]int, fourScore bool) (MovieFrame, error) {
+	f := MovieFrame{}
+
+	pads := ports[:]
+	if fourScore {
+		pads = []int{fm2InputGamepad, fm2InputGamepad, fm2InputGamepad, fm2InputGamepad}
+	}
+
+	fields := strings.Split(text, "|")
+	if len(fields) < len(pads)+2 {
+		return f, xerrors.Errorf("wrong input log: %#v", text)
+	}
+
+	c, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return f, xerrors.Errorf("wrong commands: %w", err)
+	}
+	f.Commands = MovieCommand(c)
+
+	for i := range pads {
+		if pads[i] != fm2InputGamepad {
+			continue
+		}
+		s := fields[i+2]
+		if len(s) < fm2GamepadLength {
+			return f, xerrors.Errorf("wrong gamepad input: %#v", s)
+		}
+		for j, b := range padStateBits {
+			if s[j] != '.' && s[j] != ' ' {
+				f.Pads[i] = f.Pads[i] | b.bit
+			}
+		}
+	}
+	return f, nil
+}
+
+// WriteFM2 ...
+func (m *Movie) WriteFM2(w io.Writer) error {
+	guid := m.GUID
+	if guid == "" {
+		var err error
+		guid, err = newFM2GUID()
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	header := []string{
+		fmt.Sprintf("version %d", fm2Version),
+		"emuVersion 0",
+		fmt.Sprintf("rerecordCount %d", m.RerecordCount),
+		fmt.Sprintf("palFlag %d", boolToInt(m.PAL)),
+		fmt.Sprintf("romFilename %s", m.ROMFilename),
+		fmt.Sprintf("romChecksum %s%s", fm2Base64Prefix, base64.StdEncoding.EncodeToString(m.ROMChecksum[:])),
+		fmt.Sprintf("guid %s", guid),
+		fmt.Sprintf("fourscore %d", boolToInt(m.FourScore)),
+		"microphone 0",
+		fmt.Sprintf("port0 %d", fm2InputGamepad),
+		fmt.Sprintf("port1 %d", fm2InputGamepad),
+		fmt.Sprintf("port2 %d", fm2InputNone),
+		"FDS 0",
+		"NewPPU 0",
+	}
+	for _, c := range m.Comments {
+		header = append(header, fmt.Sprintf("comment %s", c))
+	}
+	if m.SaveState != nil {
+		header = append(header, fmt.Sprintf("savestate %s%s", fm2Base64Prefix, base64.StdEncoding.EncodeToString(m.SaveState)))
+	}
+	for _, h := range header {
+		if _, err := fmt.Fprintln(bw, h); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+
+	pads := 2
+	if m.FourScore {
+		pads = 4
+	}
+	for _, f := range m.Frames {
+		line := fmt.Sprintf("|%d|", f.Commands)
+		for i := 0; i < pads; i++ {
+			line += formatFM2Gamepad(f.Pads[i]) + "|"
+		}
+		if _, err := fmt.Fprintf(bw, "%s|\n", line); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// formatFM2Gamepad ...
+func formatFM2Gamepad(s PadState) string {
+	b := make([]byte, fm2GamepadLength)
+	for i, bit := range padStateBits {
+		if s&bit.bit == bit.bit {
+			b[i] = bit.char
+		} else {
+			b[i] = '.'
+		}
+	}
+	return string(b)
+}
+
+// decodeFM2Base64 ... "base64:"で始まる値をデコード
+func decodeFM2Base64(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, fm2Base64Prefix) {
+		return nil, xerrors.Errorf("unsupported encoding: %#v", value)
+	}
+	b, err := base64.StdEncoding.DecodeString(value[len(fm2Base64Prefix):])
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return b, nil
+}
+
+// newFM2GUID ...
+func newFM2GUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", xerrors.Errorf(": %w", err)
+	}
+	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
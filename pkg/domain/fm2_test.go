@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadFM2(t *testing.T) {
+	src := strings.Join([]string{
+		"version 3",
+		"emuVersion 22020",
+		"rerecordCount 12",
+		"palFlag 0",
+		"romFilename hello",
+		"romChecksum base64:AAECAwQFBgcICQoLDA0ODw==",
+		"guid 01234567-89AB-CDEF-0123-456789ABCDEF",
+		"fourscore 0",
+		"microphone 0",
+		"port0 1",
+		"port1 0",
+		"port2 0",
+		"FDS 0",
+		"NewPPU 0",
+		"comment author someone",
+		"|0|........|||",
+		"|1|R......A|||",
+		"|0|...UT.B.|||",
+	}, "\n")
+
+	got, err := ReadFM2(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("failed to read: %+v", err)
+	}
+
+	want := &Movie{
+		ROMFilename:   "hello",
+		ROMChecksum:   [16]byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F},
+		GUID:          "01234567-89AB-CDEF-0123-456789ABCDEF",
+		RerecordCount: 12,
+		PAL:           false,
+		Comments:      []string{"author someone"},
+		Frames: []MovieFrame{
+			{Commands: 0, Pads: [4]PadState{0x00, 0x00}},
+			{Commands: MovieCommandSoftReset, Pads: [4]PadState{0x81, 0x00}},
+			{Commands: 0, Pads: [4]PadState{0x1A, 0x00}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+	}
+
+	if !got.Frames[2].Pads[0].IsPressed(ButtonTypeStart) || got.Frames[2].Pads[0].IsPressed(ButtonTypeSelect) {
+		t.Errorf("wrong pad state: %#v", got.Frames[2].Pads[0])
+	}
+}
+
+func TestMovieWriteFM2(t *testing.T) {
+	m := &Movie{
+		ROMFilename: "hello",
+		ROMChecksum: [16]byte{0xFF},
+		GUID:        "01234567-89AB-CDEF-0123-456789ABCDEF",
+		Comments:    []string{"author nes-go"},
+		SaveState:   []byte{0x01, 0x02, 0x03},
+		Frames: []MovieFrame{
+			{Commands: MovieCommandPowerCycle, Pads: [4]PadState{0x01, 0x80}},
+			{Commands: 0, Pads: [4]PadState{0xFF, 0x00}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteFM2(&buf); err != nil {
+		t.Fatalf("failed to write: %+v", err)
+	}
+	if !strings.Contains(buf.String(), "|2|.......A|R.......||\n|0|RLDUTSBA|........||\n") {
+		t.Errorf("wrong input log\ngot: %v", buf.String())
+	}
+
+	got, err := ReadFM2(&buf)
+	if err != nil {
+		t.Fatalf("failed to read: %+v", err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", m, got)
+	}
+}
+
+func TestMovieWriteFM2FourScore(t *testing.T) {
+	m := &Movie{
+		ROMFilename: "hello",
+		GUID:        "01234567-89AB-CDEF-0123-456789ABCDEF",
+		FourScore:   true,
+		Comments:    []string{"author nes-go"},
+		Frames: []MovieFrame{
+			{Commands: 0, Pads: [4]PadState{0x01, 0x02, 0x04, 0x80}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteFM2(&buf); err != nil {
+		t.Fatalf("failed to write: %+v", err)
+	}
+	if !strings.Contains(buf.String(), "fourscore 1\n") || !strings.Contains(buf.String(), "|0|.......A|......B.|.....S..|R.......||\n") {
+		t.Errorf("wrong input log\ngot: %v", buf.String())
+	}
+
+	got, err := ReadFM2(&buf)
+	if err != nil {
+		t.Fatalf("failed to read: %+v", err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", m, got)
+	}
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// FrameHandoffMode ... 表示側がフレームを受け取る前に次のフレームが完成した場合の動作
+type FrameHandoffMode int
+
+const (
+	// FrameHandoffDrop ... 受け取られていないフレームを捨てて新しいフレームに置き換える（エミュレーションは止めない）
+	FrameHandoffDrop FrameHandoffMode = iota
+	// FrameHandoffBlock ... 表示側が受け取るまでエミュレーション側を待たせる（表示の更新に同期する）
+	FrameHandoffBlock
+)
+
+// String ...
+func (m FrameHandoffMode) String() string {
+	switch m {
+	case FrameHandoffDrop:
+		return "drop"
+	case FrameHandoffBlock:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseFrameHandoffMode ... drop/blockからFrameHandoffModeを取得
+func ParseFrameHandoffMode(s string) (FrameHandoffMode, error) {
+	for _, m := range []FrameHandoffMode{FrameHandoffDrop, FrameHandoffBlock} {
+		if strings.EqualFold(s, m.String()) {
+			return m, nil
+		}
+	}
+	return FrameHandoffDrop, xerrors.Errorf("unknown frame handoff mode: %v", s)
+}
+
+// FrameMailbox ... エミュレーションのgoroutineから表示のgoroutineへフレームを受け渡す
+// バッファの所有権は AcquireBuffer/Take で取得した側に移り、Put/Release で手放す
+// （エミュレーション側が書き込み中、受け渡し待ち、表示側が使用中の最大3つのバッファを使い回す）
+type FrameMailbox struct {
+	mode FrameHandoffMode
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending []byte   // 表示側が受け取っていないフレーム
+	free    [][]byte // 再利用できるバッファ
+	closed  bool
+	dropped int
+}
+
+// NewFrameMailbox ...
+func NewFrameMailbox(mode FrameHandoffMode) *FrameMailbox {
+	m := &FrameMailbox{
+		mode: mode,
+		free: [][]byte{},
+	}
+	m.cond = sync.NewCond(&m.mu)
+	return m
+}
+
+// AcquireBuffer ... エミュレーション側が書き込むバッファを取得する
+func (m *FrameMailbox) AcquireBuffer(size int) []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for len(m.free) > 0 {
+		last := len(m.free) - 1
+		b := m.free[last]
+		m.free = m.free[:last]
+		if len(b) == size {
+			return b
+		}
+	}
+	return make([]byte, size)
+}
+
+// Put ... 完成したフレームを渡す（FrameHandoffBlockの場合は前のフレームが受け取られるまで待つ）
+func (m *FrameMailbox) Put(frame []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.mode == FrameHandoffBlock {
+		for m.pending != nil && !m.closed {
+			m.cond.Wait()
+		}
+	}
+
+	if m.pending != nil {
+		m.free = append(m.free, m.pending)
+		m.dropped++
+	}
+	m.pending = frame
+}
+
+// Take ... 表示側がフレームを受け取る（新しいフレームがない場合はfalse）
+func (m *FrameMailbox) Take() ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.pending == nil {
+		return nil, false
+	}
+	frame := m.pending
+	m.pending = nil
+	m.cond.Broadcast()
+	return frame, true
+}
+
+// Release ... 表示側が使い終わったバッファを返す
+func (m *FrameMailbox) Release(frame []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.free = append(m.free, frame)
+}
+
+// Close ... 待っているエミュレーション側を解放する（以降のPutは待たない）
+func (m *FrameMailbox) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	m.cond.Broadcast()
+}
+
+// GetDroppedCount ... 表示されずに捨てられたフレーム数
+func (m *FrameMailbox) GetDroppedCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.dropped
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestFrameMailbox(t *testing.T) {
+	t.Run("when mode is drop, the latest frame is taken", func(t *testing.T) {
+		m := NewFrameMailbox(FrameHandoffDrop)
+		m.Put([]byte{1})
+		m.Put([]byte{2})
+
+		got, ok := m.Take()
+		if !ok || !reflect.DeepEqual(got, []byte{2}) {
+			t.Errorf("wrong output\nwant: %#v\ngot: %#v", []byte{2}, got)
+		}
+		if _, ok := m.Take(); ok {
+			t.Errorf("frame is taken twice")
+		}
+		if m.GetDroppedCount() != 1 {
+			t.Errorf("wrong dropped count\nwant: %#v\ngot: %#v", 1, m.GetDroppedCount())
+		}
+	})
+
+	t.Run("when mode is block, all frames are taken in order", func(t *testing.T) {
+		m := NewFrameMailbox(FrameHandoffBlock)
+		const count = 100
+
+		go func() {
+			for i := 0; i < count; i++ {
+				b := m.AcquireBuffer(1)
+				b[0] = byte(i)
+				m.Put(b)
+			}
+		}()
+
+		for i := 0; i < count; {
+			b, ok := m.Take()
+			if !ok {
+				runtime.Gosched()
+				continue
+			}
+			if b[0] != byte(i) {
+				t.Fatalf("wrong output\nwant: %#v\ngot: %#v", i, b[0])
+			}
+			m.Release(b)
+			i++
+		}
+		if m.GetDroppedCount() != 0 {
+			t.Errorf("wrong dropped count\nwant: %#v\ngot: %#v", 0, m.GetDroppedCount())
+		}
+	})
+}
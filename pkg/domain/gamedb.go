@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// GameInfo ... ヘッダから判別できないゲームの情報
+type GameInfo struct {
+	Title  string
+	Region Region
+}
+
+// gameDatabase ... PRG-ROMとCHR-ROMのCRC32をキーとしたゲーム情報
+// iNES 1.0形式のヘッダは地域を正しく記録していないことが多いため、ここに登録したものを優先する
+var gameDatabase = map[uint32]GameInfo{}
+
+// LoadGameDatabase ... ゲームデータベースのファイルを読み込み、登録済みの情報に追加する
+// 1行に1ゲームを「CRC32（16進数） 地域（ntsc/pal/dendy） タイトル」の形式で書く。空行と#で始まる行は無視する
+// エミュレーションを開始する前に呼び出すこと
+func LoadGameDatabase(r io.Reader) error {
+	games := map[uint32]GameInfo{}
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 2 {
+			return xerrors.Errorf("invalid game database entry; line: %v, entry: %#v", line, text)
+		}
+		crc, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			return xerrors.Errorf("invalid crc32; line: %v: %w", line, err)
+		}
+		region, err := ParseRegion(fields[1])
+		if err != nil {
+			return xerrors.Errorf("line: %v: %w", line, err)
+		}
+		games[uint32(crc)] = GameInfo{
+			Title:  strings.Join(fields[2:], " "),
+			Region: region,
+		}
+	}
+	if err := s.Err(); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	// 途中で失敗した場合は何も登録しない
+	for crc, g := range games {
+		gameDatabase[crc] = g
+	}
+	return nil
+}
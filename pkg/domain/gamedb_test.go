@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadGameDatabase(t *testing.T) {
+	original := gameDatabase
+	defer func() { gameDatabase = original }()
+
+	// hello-world.nesのPRG-ROMとCHR-ROMのCRC32
+	const helloWorldCRC32 = 0x4400FF8F
+
+	tests := []struct {
+		name    string
+		db      string
+		want    Region
+		wantErr bool
+	}{
+		{
+			name: "when crc32 is registered as pal, return pal",
+			db:   "# comment\n\n4400FF8F pal Hello, World! (Europe)\n",
+			want: RegionPAL,
+		},
+		{
+			name: "when crc32 is lower case, return its region",
+			db:   "4400ff8f dendy Hello, World!\n",
+			want: RegionDendy,
+		},
+		{
+			name: "when crc32 is not registered, return region from header",
+			db:   "12345678 pal Another Game\n",
+			want: RegionNTSC,
+		},
+		{
+			name:    "when region is unknown, return error and register nothing",
+			db:      "12345678 pal Another Game\n4400FF8F secam Hello, World!\n",
+			want:    RegionNTSC,
+			wantErr: true,
+		},
+		{
+			name:    "when region is missing, return error",
+			db:      "4400FF8F\n",
+			want:    RegionNTSC,
+			wantErr: true,
+		},
+	}
+
+	rom, err := FetchROM("../../test/roms/hello-world/hello-world.nes")
+	if err != nil {
+		t.Fatalf("failed to fetch rom; %v", err)
+	}
+	if rom.CRC32 != helloWorldCRC32 {
+		t.Fatalf("wrong crc32\nwant: %08X\ngot: %08X", helloWorldCRC32, rom.CRC32)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameDatabase = map[uint32]GameInfo{}
+
+			err := LoadGameDatabase(strings.NewReader(tt.db))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wrong error\nwantErr: %#v\ngot: %#v", tt.wantErr, err)
+			}
+			if tt.wantErr && len(gameDatabase) != 0 {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", map[uint32]GameInfo{}, gameDatabase)
+			}
+
+			if got := rom.GetRegion(); got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
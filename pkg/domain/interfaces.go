@@ -1,27 +1,54 @@
 package domain
 
+import "image/color"
+
+// StateSaver ... セーブステートに対応するコンポーネント
+type StateSaver interface {
+	MarshalState() ([]byte, error)
+	UnmarshalState([]byte) error
+}
+
 // CPU ...
 type CPU interface {
+	StateSaver
 	SetBus(Bus)
 	SetRecorder(*Recorder)
+	SetCatchUp(func(cycle int) error) // PPUのレジスタなどにアクセスする前に、命令の先頭からのサイクル数を渡して呼ぶ関数
 	Run() (int, error)
 	String() string
 	ReceiveNMI(active bool)
+	IsJammed() bool
+	PowerOn(PowerOnProfile)
+	Reset()
+}
+
+// Memory ... CPUから見たメモリ空間
+type Memory interface {
+	Read(Address) (byte, error)
+	Write(Address, byte) error
 }
 
 // PPU ...
 type PPU interface {
+	StateSaver
 	SetBus(Bus)
 	SetRecorder(*Recorder)
 	ReadRegisters(Address) (byte, error)
 	WriteRegisters(Address, byte) error
 	Run(int) (*Screen, error)
 	String() string
+	SetRegion(Region)
+	PowerOn()
+	Reset()
+	SetWarmUp(bool)
+	GetOutputPixel(x, y int) (c color.RGBA, dotsAgo int, ok bool) // 現在のフレームで出力済の画素と、出力してから経過したdot数
 }
 
 // Bus ...
 type Bus interface {
-	Setup(*ROM, PPU, CPU, *VRAM, Pad, Pad)
+	StateSaver
+	Setup(*ROM, PPU, CPU, *VRAM, PortDevice, PortDevice)
+	PowerOn(PowerOnProfile)
 	ReadByCPU(Address) (byte, error)
 	WriteByCPU(Address, byte) error
 	ReadByPPU(Address) (byte, error)
@@ -33,10 +60,63 @@ type Bus interface {
 	GetPalette(uint8) *Palette
 	GetAttribute(uint8, NameTablePoint) (byte, error)
 	SendNMI(active bool)
+	SetOAMDMA(func(page byte) error) // $4014への書き込みで呼ぶ関数（OAM DMAを開始する）
+	GetPRGRAM() []byte
+}
+
+// PortDevice ... コントローラーポート（$4016/$4017）に接続する機器
+type PortDevice interface {
+	StateSaver
+	Strobe(out byte) // $4016への書き込み（OUT0-OUT2）、両方のポートに通知する
+	Read() byte      // $4016/$4017の読み込み（D0-D4のみ、上位ビットはBusがオープンバスで埋める）
+	Peek() byte      // 状態を変えずにReadと同じ値を返す（トレース用）
+}
+
+// ExpansionDevice ... ファミコンの拡張端子に接続する機器
+// 拡張端子は$4016・$4017の両方のD1-D4につながっている（port 0: $4016, 1: $4017）
+type ExpansionDevice interface {
+	StateSaver
+	Strobe(out byte)
+	Read(port int) byte // D1-D4以外のビットは無視する
+	Peek(port int) byte // 状態を変えずにReadと同じ値を返す（トレース用）
+}
+
+// InputDevice ... フレームごとに入力を読み込む機器（NESがパッドと同じタイミングでLoadを呼ぶ）
+type InputDevice interface {
+	Load() error
+}
+
+// ZapperInput ... Zapperの照準と引き金の入力
+type ZapperInput interface {
+	InputDevice
+	GetAim() (x, y int, onScreen bool) // 画面上の座標（画面外を狙っている場合はonScreenがfalse）
+	IsTriggerPulled() bool
+}
+
+// VausInput ... Vaus（アルカノイドのパドル）のつまみと発射ボタンの入力
+type VausInput interface {
+	InputDevice
+	GetPosition() float64 // つまみの位置（0: 左端, 1: 右端）
+	IsFirePressed() bool
+}
+
+// KeyboardInput ... ファミリーベーシックのキーボードの入力
+type KeyboardInput interface {
+	InputDevice
+	IsKeyPressed(k KeyboardKey) bool
+}
+
+// PowerPadInput ... Power Padのボタンの入力
+type PowerPadInput interface {
+	InputDevice
+	IsButtonPressed(button int) bool // 使っている面のボタンの番号（1から）
 }
 
 // Renderer ...
 type Renderer interface {
 	Run() error
 	Render(*Screen) error
+	SetFrameRate(float64)
+	AddUpdateHook(func() error)
+	Close()
 }
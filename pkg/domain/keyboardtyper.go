@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"nes-go/pkg/log"
+	"unicode"
+)
+
+const (
+	// typePressFrames ... 1文字分のキーを押しているフレーム数
+	typePressFrames = 3
+	// typeReleaseFrames ... 次の文字を打つまでキーを離しているフレーム数（同じ文字が続いても別の入力になるように）
+	typeReleaseFrames = 3
+)
+
+// shiftedKeys ... SHIFTを押して入力する文字と、そのときに押すキー
+var shiftedKeys = map[rune]KeyboardKey{
+	'!': "1", '"': "2", '#': "3", '$': "4", '%': "5", '&': "6", '\'': "7", '(': "8", ')': "9",
+	'=': "-", '+': ";", '*': ":", '<': ",", '>': ".", '?': "/",
+}
+
+// textKeys ... 文字を入力するために同時に押すキー（入力できない文字の場合はfalse）
+// 英字は大文字・小文字とも同じキーにする（ファミリーベーシックは大文字のみ）
+func textKeys(r rune) ([]KeyboardKey, bool) {
+	switch r {
+	case ' ':
+		return []KeyboardKey{KeyboardKeySpace}, true
+	case '\n', '\r':
+		return []KeyboardKey{KeyboardKeyReturn}, true
+	case '\\':
+		return []KeyboardKey{"¥"}, true
+	}
+	if k, ok := shiftedKeys[r]; ok {
+		return []KeyboardKey{KeyboardKeyLeftShift, k}, true
+	}
+
+	k, err := ParseKeyboardKey(string(unicode.ToUpper(r)))
+	if err != nil || len([]rune(string(k))) != 1 {
+		// F1やSTOPなどの名前のキーは文字としては入力しない
+		return nil, false
+	}
+	return []KeyboardKey{k}, true
+}
+
+// KeyboardTyper ... 文字列を1文字ずつキーボードの入力にする
+// Stepを1フレームに1回呼び、返ってきたキーを押していることにする
+type KeyboardTyper struct {
+	queue   [][]KeyboardKey
+	current []KeyboardKey
+	frame   int // currentを押し始めてからのフレーム数
+}
+
+// NewKeyboardTyper ...
+func NewKeyboardTyper() *KeyboardTyper {
+	return &KeyboardTyper{
+		queue: [][]KeyboardKey{},
+	}
+}
+
+// Type ... 入力する文字列を追加する（入力できない文字は無視する）
+func (t *KeyboardTyper) Type(s string) {
+	for _, r := range s {
+		keys, ok := textKeys(r)
+		if !ok {
+			log.Debug("KeyboardTyper.Type: ignored %q", r)
+			continue
+		}
+		t.queue = append(t.queue, keys)
+	}
+}
+
+// IsTyping ... 入力中の文字があるか
+func (t *KeyboardTyper) IsTyping() bool {
+	return t.current != nil || len(t.queue) > 0
+}
+
+// Step ... 1フレーム進め、このフレームに押すキーを返す
+func (t *KeyboardTyper) Step() []KeyboardKey {
+	if t.current == nil {
+		if len(t.queue) == 0 {
+			return nil
+		}
+		t.current = t.queue[0]
+		t.queue = t.queue[1:]
+		t.frame = 0
+	}
+
+	keys := t.current
+	if t.frame >= typePressFrames {
+		keys = nil
+	}
+	t.frame++
+	if t.frame >= typePressFrames+typeReleaseFrames {
+		t.current = nil
+	}
+	return keys
+}
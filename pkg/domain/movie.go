@@ -0,0 +1,121 @@
+package domain
+
+// PadState ... 1フレーム分のパッドの入力（FM2の並び順 RLDUTSBA のビット）
+type PadState uint8
+
+// padStateBits ... FM2の並び順（上位ビットから）
+var padStateBits = []struct {
+	button ButtonType
+	bit    PadState
+	char   byte
+}{
+	{ButtonTypeRight, 0x80, 'R'},
+	{ButtonTypeLeft, 0x40, 'L'},
+	{ButtonTypeDown, 0x20, 'D'},
+	{ButtonTypeUp, 0x10, 'U'},
+	{ButtonTypeStart, 0x08, 'T'},
+	{ButtonTypeSelect, 0x04, 'S'},
+	{ButtonTypeB, 0x02, 'B'},
+	{ButtonTypeA, 0x01, 'A'},
+}
+
+// NewPadState ... padの現在の入力を取得
+func NewPadState(p Pad) PadState {
+	var s PadState
+	for _, b := range padStateBits {
+		if p.IsPressed(b.button) {
+			s = s | b.bit
+		}
+	}
+	return s
+}
+
+// padLatcher ... コントローラーがボタンの状態を読み込む（ラッチする）ときに入力を読み込むパッド
+type padLatcher interface {
+	latch() PadState
+}
+
+// latchPadState ... コントローラーがラッチするときのpadの入力（padLatcherの場合はその時点の入力を読み込む）
+func latchPadState(p Pad) PadState {
+	if l, ok := p.(padLatcher); ok {
+		return l.latch()
+	}
+	return NewPadState(p)
+}
+
+// IsPressed ...
+func (s PadState) IsPressed(button ButtonType) bool {
+	bit := padStateBit(button)
+	return bit != 0 && s&bit == bit
+}
+
+// padStateBit ... buttonに対応するビット（PadStateで扱わないボタンの場合は0）
+func padStateBit(button ButtonType) PadState {
+	for _, b := range padStateBits {
+		if b.button == button {
+			return b.bit
+		}
+	}
+	return 0
+}
+
+// MovieCommand ... フレームの先頭で実行するコマンド（FM2と同じビット）
+type MovieCommand uint8
+
+const (
+	// MovieCommandSoftReset ... リセット
+	MovieCommandSoftReset MovieCommand = 0x01
+	// MovieCommandPowerCycle ... 電源の入れ直し
+	MovieCommandPowerCycle MovieCommand = 0x02
+)
+
+// MovieFrame ... 1フレーム分の入力
+type MovieFrame struct {
+	Commands MovieCommand
+	Pads     [4]PadState // 1P-4P（Four Scoreを使わない場合は3P・4Pは常に0）
+}
+
+// Movie ... 入力の記録
+type Movie struct {
+	ROMFilename   string
+	ROMChecksum   [16]byte // PRG-ROMとCHR-ROMのMD5
+	GUID          string
+	RerecordCount int
+	PAL           bool
+	FourScore     bool // 4人分の入力を記録しているか（MultitapFourScore・MultitapFamicom）
+	Comments      []string
+	SaveState     []byte // 開始時のセーブステート（nilの場合は電源投入から開始）
+	Frames        []MovieFrame
+}
+
+// movieSession ... 記録中もしくは再生中のムービー
+type movieSession struct {
+	movie   *Movie
+	playing bool
+	frame   int        // 次に記録・再生するフレーム
+	current MovieFrame // 再生中のフレーム
+
+	pendingCommands MovieCommand // 記録中に要求され、次のフレームの先頭で実行するコマンド
+}
+
+// padPort ... Busに接続するパッド
+// コントローラーがラッチするときにNES.latchPadで入力を読み込み（ムービー再生中は記録された入力）、IsPressedは最後に読み込んだ入力を返す
+type padPort struct {
+	nes  *NES
+	port int
+}
+
+// Load ... 入力の読み込みはラッチするときに行う
+func (p *padPort) Load() error {
+	return nil
+}
+
+// IsPressed ...
+func (p *padPort) IsPressed(b ButtonType) bool {
+	return p.nes.padStates[p.port].IsPressed(b)
+}
+
+// latch ...
+func (p *padPort) latch() PadState {
+	return p.nes.latchPad(p.port)
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"math/bits"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Multitap ... 4人同時プレイのアダプタ
+type Multitap int
+
+const (
+	// MultitapNone ... アダプタなし（1P・2Pのみ）
+	MultitapNone Multitap = iota
+	// MultitapFourScore ... NESのFour Score（$4016/$4017から24bitずつ読む）
+	MultitapFourScore
+	// MultitapFamicom ... ファミコンの拡張端子に3P・4Pを接続するアダプタ（$4016/$4017のD1）
+	MultitapFamicom
+)
+
+// String ...
+func (m Multitap) String() string {
+	switch m {
+	case MultitapNone:
+		return "none"
+	case MultitapFourScore:
+		return "fourscore"
+	case MultitapFamicom:
+		return "famicom"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseMultitap ... none/fourscore/famicomからMultitapを取得
+func ParseMultitap(s string) (Multitap, error) {
+	for _, m := range []Multitap{MultitapNone, MultitapFourScore, MultitapFamicom} {
+		if strings.EqualFold(s, m.String()) {
+			return m, nil
+		}
+	}
+	return MultitapNone, xerrors.Errorf("unknown multitap: %v", s)
+}
+
+const (
+	// FourScoreSignature1 ... Four Scoreが$4016の17-24回目の読み込みで最上位ビットから返す値（20回目が1）
+	FourScoreSignature1 = 0x10
+	// FourScoreSignature2 ... Four Scoreが$4017の17-24回目の読み込みで最上位ビットから返す値（19回目が1）
+	FourScoreSignature2 = 0x20
+)
+
+// FourScorePort ... Four Scoreの片方のポート（2台分のコントローラーとシグネチャの24bitのシフトレジスタ）
+// $4016は1P・3P、$4017は2P・4Pの順に8bitずつ読み、続けてシグネチャを読む。25回目以降は0を返す
+// 仕様: https://wiki.nesdev.com/w/index.php/Four_player_adapters
+type FourScorePort struct {
+	pads      [2]Pad
+	signature byte
+	strobe    bool
+	shift     uint32
+}
+
+// NewFourScorePort ...
+func NewFourScorePort(first, second Pad, signature byte) *FourScorePort {
+	return &FourScorePort{
+		pads:      [2]Pad{first, second},
+		signature: signature,
+	}
+}
+
+// report ... 読み出し順に並べた24bit（コントローラーは最下位ビット、シグネチャは最上位ビットから読むので反転する）
+func (f *FourScorePort) report(first, second PadState) uint32 {
+	return uint32(first) | uint32(second)<<8 | uint32(bits.Reverse8(f.signature))<<16
+}
+
+// latch ... コントローラーの状態を読み込む
+func (f *FourScorePort) latch() {
+	f.shift = f.report(latchPadState(f.pads[0]), latchPadState(f.pads[1]))
+}
+
+// Strobe ... ストローブが1の間と、1から0になったときにコントローラーの状態を読み込む
+func (f *FourScorePort) Strobe(out byte) {
+	prev := f.strobe
+	f.strobe = out&PortStrobe == PortStrobe
+	if f.strobe || prev {
+		f.latch()
+	}
+}
+
+// Read ...
+func (f *FourScorePort) Read() byte {
+	if f.strobe {
+		f.latch()
+		return byte(f.shift & 0x01)
+	}
+
+	d := byte(f.shift & 0x01)
+	f.shift = f.shift >> 1
+	return d
+}
+
+// Peek ...
+func (f *FourScorePort) Peek() byte {
+	if f.strobe {
+		return byte(f.report(NewPadState(f.pads[0]), NewPadState(f.pads[1])) & 0x01)
+	}
+	return byte(f.shift & 0x01)
+}
+
+// fourScorePortState ... セーブステート用の状態
+type fourScorePortState struct {
+	Strobe bool
+	Shift  uint32
+}
+
+// MarshalState ...
+func (f *FourScorePort) MarshalState() ([]byte, error) {
+	data, err := EncodeState(fourScorePortState{Strobe: f.strobe, Shift: f.shift})
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (f *FourScorePort) UnmarshalState(data []byte) error {
+	s := fourScorePortState{}
+	if err := DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	f.strobe = s.Strobe
+	f.shift = s.Shift
+	return nil
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// readPort ... ストローブしてからn回読み込む
+func readPort(p PortDevice, n int) []byte {
+	p.Strobe(1)
+	p.Strobe(0)
+	got := []byte{}
+	for i := 0; i < n; i++ {
+		got = append(got, p.Read())
+	}
+	return got
+}
+
+func TestFourScorePort(t *testing.T) {
+	first := &stubPad{pressed: map[ButtonType]bool{ButtonTypeA: true, ButtonTypeRight: true}}
+	second := &stubPad{pressed: map[ButtonType]bool{ButtonTypeB: true}}
+
+	tests := []struct {
+		name      string
+		signature byte
+		want      []byte
+	}{
+		{
+			name:      "when port 1, read pad1, pad3, signature 0x10 from MSB (1 on the 20th read) and then 0",
+			signature: FourScoreSignature1,
+			want: []byte{
+				1, 0, 0, 0, 0, 0, 0, 1,
+				0, 1, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 1, 0, 0, 0, 0,
+				0, 0,
+			},
+		},
+		{
+			name:      "when port 2, read signature 0x20 from MSB (1 on the 19th read)",
+			signature: FourScoreSignature2,
+			want: []byte{
+				1, 0, 0, 0, 0, 0, 0, 1,
+				0, 1, 0, 0, 0, 0, 0, 0,
+				0, 0, 1, 0, 0, 0, 0, 0,
+				0, 0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readPort(NewFourScorePort(first, second, tt.signature), len(tt.want))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFamicomExpansionPort(t *testing.T) {
+	pad1 := &stubPad{pressed: map[ButtonType]bool{ButtonTypeA: true}}
+	pad2 := &stubPad{pressed: map[ButtonType]bool{}}
+	pad3 := &stubPad{pressed: map[ButtonType]bool{ButtonTypeB: true, ButtonTypeStart: true}}
+	pad4 := &stubPad{pressed: map[ButtonType]bool{ButtonTypeSelect: true}}
+	expansion := NewExpansionControllers(pad3, pad4)
+	port1 := NewFamicomExpansionPort(pad1, expansion, 0)
+	port2 := NewFamicomExpansionPort(pad2, expansion, 1)
+
+	// $4016への書き込みは両方のポートをストローブする
+	port1.Strobe(1)
+	port2.Strobe(1)
+	port1.Strobe(0)
+	port2.Strobe(0)
+	got := [2][]byte{}
+	for i := 0; i < 9; i++ {
+		got[0] = append(got[0], port1.Read())
+		got[1] = append(got[1], port2.Read())
+	}
+
+	want := [2][]byte{
+		{0x01, 0x02, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x03},
+		{0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+	}
+}
@@ -1,6 +1,15 @@
 package domain
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
 	"golang.org/x/xerrors"
 	"nes-go/pkg/log"
 )
@@ -12,13 +21,62 @@ type NES struct {
 	PPU      PPU
 	Pad1     Pad
 	Pad2     Pad
-	Renderer Renderer
-	Recorder *Recorder
+	Pad3     Pad        // Multitapを使う場合のみ
+	Pad4     Pad        // Multitapを使う場合のみ
+	Port1    PortDevice // nilの場合はPad1を接続した標準コントローラー（Multitapを使う場合は指定できない）
+	Port2    PortDevice // nilの場合はPad2を接続した標準コントローラー（Multitapを使う場合は指定できない）
+	Multitap Multitap   // 4人同時プレイのアダプタ
+
+	Expansion ExpansionDevice // nil以外の場合はファミコンの拡張端子に接続する（Port1・Port2・Multitapとは同時に使えない）
+	Renderer  Renderer
+	Recorder  *Recorder
+	PowerOn   *PowerOnProfile // nilの場合はDefaultPowerOnProfile
+	Rewind    *RewindConfig   // nilの場合は巻き戻ししない
+	Region    *Region         // nilの場合はROMのヘッダとゲームDBから判定
+	Trace     io.Writer       // nil以外の場合は実行した命令をnestest.logの形式で書き出す
+
+	TurboRate int // 連射ボタンの周期（フレーム数）、0の場合はDefaultTurboRate
+
+	FaultPolicy FaultPolicy // CPUが停止したり、実行できないオペコードに遭遇したときの動作
+
+	romPath string
+	rom     *ROM
+	vram    *VRAM
+	region  Region
+	timing  RegionTiming
+
+	scheduler   *Scheduler
+	cpuID       int    // スケジューラに登録したCPUの番号
+	warmUpUntil uint64 // PPUのウォームアップが終わるマスタークロック
+	oamDMA      oamDMA // 転送中のOAM DMA
 
-	ppuDelayCycle  int
-	cpuBeforeCycle int
+	frameCompleted bool    // 直前のRun1Cycleで1フレームの描画が完了したか
+	screen         *Screen // 最後に完成したフレーム（PPUが次のフレームを返すまで有効）
+	frameCount     int
+	rewindBuffer   *RewindBuffer
+	rewinding      bool
+	rewindEmpty    bool // 巻き戻し中にスナップショットがなくなったか
+
+	padStates     [4]PadState // コントローラーが最後にラッチした1P-4Pの入力（連射を反映済）
+	padLatched    [4]bool     // ムービーの記録中に、現在のフレームで入力を読み込んだか
+	padErr        error       // ラッチしたときにパッドの読み込みで発生したエラー（Run1Cycleで返す）
+	movie         *movieSession
+	movieCommands MovieCommand // 直前のRun1Cycleで読み込んだ、次に実行するムービーのコマンド
+
+	stopping      bool // Runの終了処理中か
+	paused        bool
+	advanceFrames int     // 一時停止中に進めるフレーム数
+	speed         float64 // 実行速度の倍率（SpeedUnthrottledの場合は制限なし）
+
+	mu   sync.Mutex // エミュレーションを実行するgoroutineとそれ以外からの操作を排他する
+	cond *sync.Cond // 一時停止・巻き戻しの解除を通知する
 }
 
+const (
+	// ppuDelayCycle ... 電源投入時にPPUがCPUより遅れて動き始めるサイクル数（CPUサイクル）
+	ppuDelayCycle = 7
+)
+
 // Setup ...
 func (n *NES) Setup(p string) error {
 	rom, err := FetchROM(p)
@@ -28,78 +86,932 @@ func (n *NES) Setup(p string) error {
 
 	vram := NewVRAM()
 
-	n.Bus.Setup(rom, n.PPU, n.CPU, vram, n.Pad1, n.Pad2)
+	n.romPath = p
+	n.rom = rom
+	n.vram = vram
+	n.region = rom.GetRegion()
+	if n.Region != nil {
+		n.region = *n.Region
+	}
+	n.timing = n.region.GetTiming()
+	log.Info("region: %v", n.region)
+
+	if err := n.setupPorts(); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	n.Bus.Setup(rom, n.PPU, n.CPU, vram, n.Port1, n.Port2)
 	n.CPU.SetBus(n.Bus)
 	n.PPU.SetBus(n.Bus)
+	n.CPU.SetCatchUp(n.catchUp)
+	n.Bus.SetOAMDMA(n.startOAMDMA)
 
 	n.CPU.SetRecorder(n.Recorder)
 	n.PPU.SetRecorder(n.Recorder)
+	n.PPU.SetRegion(n.region)
+
+	if n.Rewind != nil {
+		n.rewindBuffer = NewRewindBuffer(*n.Rewind)
+	}
+
+	if n.TurboRate == 0 {
+		n.TurboRate = DefaultTurboRate
+	}
+	if err := validateTurboRate(n.TurboRate); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	n.cond = sync.NewCond(&n.mu)
+	n.speed = 1
 
-	n.ppuDelayCycle = 7
+	n.powerCycle()
+
+	if rom.Header.HasBattery() {
+		if err := loadBatteryRAM(n.GetBatteryPath(), n.Bus.GetPRGRAM()); err != nil {
+			return xerrors.Errorf("failed to load battery: %w", err)
+		}
+	}
 
 	return nil
 }
 
-// Run1Cycle ...
-func (n *NES) Run1Cycle() error {
-	defer log.Debug(n.Recorder.String())
+// PowerCycle ... 電源を入れ直す（ムービー記録中は次のフレームの先頭で実行する）
+// エミュレーション実行中でも呼び出せる
+func (n *NES) PowerCycle() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.movie != nil && !n.movie.playing {
+		n.movie.pendingCommands = n.movie.pendingCommands | MovieCommandPowerCycle
+		return
+	}
+	n.powerCycle()
+}
+
+// powerCycle ...
+func (n *NES) powerCycle() {
+	profile := DefaultPowerOnProfile()
+	if n.PowerOn != nil {
+		profile = *n.PowerOn
+	}
+
+	n.Bus.PowerOn(profile)
+	n.CPU.PowerOn(profile)
+	n.PPU.PowerOn()
+
+	// 同時刻の場合はCPUを先に実行する
+	n.scheduler = NewScheduler()
+	n.cpuID = n.scheduler.AddComponent("CPU", n.timing.CPUDivider, 0, n.stepCPU)
+	n.scheduler.AddComponent("PPU", n.timing.PPUDivider, uint64(ppuDelayCycle*n.timing.CPUDivider), n.stepPPU)
+
+	n.warmUpUntil = uint64(profile.PPUWarmUpCycles * n.timing.CPUDivider)
+	n.scheduleWarmUp()
+	n.oamDMA = oamDMA{}
+
+	n.frameCount = 0
+	if n.rewindBuffer != nil {
+		n.rewindBuffer.Clear()
+	}
+}
+
+// scheduleWarmUp ... PPUのウォームアップが終わるイベントを予約
+func (n *NES) scheduleWarmUp() {
+	clock := n.scheduler.GetClock()
+	if clock >= n.warmUpUntil {
+		n.PPU.SetWarmUp(false)
+		return
+	}
+
+	n.PPU.SetWarmUp(true)
+	n.scheduler.ScheduleEvent("PPU warm-up", n.warmUpUntil-clock, func() error {
+		n.PPU.SetWarmUp(false)
+		return nil
+	})
+}
+
+// Reset ... リセットボタンを押す（CPUはリセットベクタから実行を再開する）
+// ムービー記録中は次のフレームの先頭で実行する。エミュレーション実行中でも呼び出せる
+func (n *NES) Reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
 
-	if n.ppuDelayCycle <= 0 {
-		screen, err := n.PPU.Run(n.cpuBeforeCycle * 3)
+	if n.movie != nil && !n.movie.playing {
+		n.movie.pendingCommands = n.movie.pendingCommands | MovieCommandSoftReset
+		return
+	}
+	n.reset()
+}
+
+// reset ...
+func (n *NES) reset() {
+	n.CPU.Reset()
+	n.PPU.Reset()
+}
+
+// GetScheduler ...
+func (n *NES) GetScheduler() *Scheduler {
+	return n.scheduler
+}
+
+// stepCPU ... CPUで1命令実行
+func (n *NES) stepCPU() (int, error) {
+	n.Recorder.Cycle = int(n.scheduler.GetClock() / uint64(n.timing.CPUDivider))
+
+	jammed := n.CPU.IsJammed()
+	cycle, err := n.CPU.Run()
+	if err != nil {
+		return 0, xerrors.Errorf(": %w", err)
+	}
+	if n.Trace != nil {
+		if _, err := fmt.Fprintln(n.Trace, n.Recorder.String()); err != nil {
+			return cycle, xerrors.Errorf("failed to write trace: %w", err)
+		}
+	}
+	if !jammed && n.CPU.IsJammed() {
+		return cycle, xerrors.Errorf("PC: %#04X: %w", n.Recorder.PC, ErrCPUJammed)
+	}
+	return cycle, nil
+}
+
+// stepPPU ... PPUで1dot実行
+func (n *NES) stepPPU() (int, error) {
+	screen, err := n.PPU.Run(1)
+	if err != nil {
+		return 0, xerrors.Errorf(": %w", err)
+	}
+
+	if screen != nil {
+		// Rendererへの受け渡しはロックを外してからRunで行う
+		n.frameCompleted = true
+		n.screen = screen
+
+		err = n.loadPads()
 		if err != nil {
-			return xerrors.Errorf(": %w", err)
+			return 0, xerrors.Errorf(": %w", err)
 		}
+	}
+	return 1, nil
+}
 
-		if screen != nil {
-			err = n.Renderer.Render(screen)
-			if err != nil {
+// setupPorts ... Multitap・拡張端子の設定に応じてポートに機器を接続する
+// ムービー再生中に入力を差し替えられるように、コントローラーにはpadを直接接続しない
+func (n *NES) setupPorts() error {
+	pads := [4]Pad{}
+	for i := range pads {
+		pads[i] = &padPort{nes: n, port: i}
+	}
+
+	portSpecified := n.Port1 != nil || n.Port2 != nil
+	if n.Multitap != MultitapNone && (portSpecified || n.Expansion != nil) {
+		return xerrors.Errorf("port and expansion devices cannot be specified with multitap %v", n.Multitap)
+	}
+	if n.Expansion != nil && portSpecified {
+		return xerrors.New("port devices cannot be specified with an expansion device")
+	}
+
+	switch n.Multitap {
+	case MultitapNone:
+		if n.Expansion != nil {
+			n.Port1 = NewFamicomExpansionPort(pads[0], n.Expansion, 0)
+			n.Port2 = NewFamicomExpansionPort(pads[1], n.Expansion, 1)
+			log.Info("expansion: %T", n.Expansion)
+			break
+		}
+		if n.Port1 == nil {
+			n.Port1 = NewStandardController(pads[0])
+		}
+		if n.Port2 == nil {
+			n.Port2 = NewStandardController(pads[1])
+		}
+	case MultitapFourScore:
+		n.Port1 = NewFourScorePort(pads[0], pads[2], FourScoreSignature1)
+		n.Port2 = NewFourScorePort(pads[1], pads[3], FourScoreSignature2)
+	case MultitapFamicom:
+		expansion := NewExpansionControllers(pads[2], pads[3])
+		n.Port1 = NewFamicomExpansionPort(pads[0], expansion, 0)
+		n.Port2 = NewFamicomExpansionPort(pads[1], expansion, 1)
+	default:
+		return xerrors.Errorf("unknown multitap: %v", n.Multitap)
+	}
+	log.Info("multitap: %v", n.Multitap)
+	return nil
+}
+
+// getPads ... 1P-4Pのパッド（接続していないプレイヤーはnil）
+func (n *NES) getPads() [4]Pad {
+	if n.Multitap == MultitapNone {
+		return [4]Pad{n.Pad1, n.Pad2, nil, nil}
+	}
+	return [4]Pad{n.Pad1, n.Pad2, n.Pad3, n.Pad4}
+}
+
+// loadPads ... 次のフレームの入力を読み込む（ムービーの記録・再生もここで行う）
+// ムービーを記録・再生していない場合、パッドの入力はコントローラーがラッチするときに読み込む（latchPadを参照）
+func (n *NES) loadPads() error {
+	// 標準コントローラー以外の入力はムービーに記録しないので、再生中も読み込む
+	for _, d := range []interface{}{n.Port1, n.Port2, n.Expansion} {
+		if i, ok := d.(InputDevice); ok {
+			if err := i.Load(); err != nil {
 				return xerrors.Errorf(": %w", err)
 			}
+		}
+	}
 
-			err = n.Pad1.Load()
-			if err != nil {
-				return xerrors.Errorf(": %w", err)
+	if s := n.movie; s != nil && s.playing {
+		if s.frame >= len(s.movie.Frames) {
+			log.Info("movie playback finished; frames: %v", s.frame)
+			n.movie = nil
+		} else {
+			s.current = s.movie.Frames[s.frame]
+			s.frame++
+			n.padStates = s.current.Pads
+			n.movieCommands = s.current.Commands
+			return nil
+		}
+	}
+
+	s := n.movie
+	if s == nil {
+		return nil
+	}
+
+	// 記録中はフレームの先頭の入力を記録しておき、そのフレームで最初にラッチしたときの入力で置き換える
+	for i, p := range n.getPads() {
+		if p == nil {
+			n.padStates[i] = 0
+			continue
+		}
+		if err := p.Load(); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+		n.padStates[i] = readPadState(p, n.TurboRate, n.frameCount)
+	}
+	n.padLatched = [4]bool{}
+
+	s.movie.Frames = append(s.movie.Frames, MovieFrame{
+		Commands: s.pendingCommands,
+		Pads:     n.padStates,
+	})
+	s.frame++
+	n.movieCommands = s.pendingCommands
+	s.pendingCommands = 0
+	return nil
+}
+
+// latchPad ... コントローラーがラッチするときのport（0: 1P - 3: 4P）の入力
+// ムービーにはフレームごとに1つの入力しか記録できないため、記録中はフレームで最初にラッチしたときだけパッドを読み込んで記録し、
+// 同じフレームで再びラッチしたときは同じ入力を返す（再生中は記録された入力を返す）
+// ムービーがない場合はラッチするたびにパッドを読み込むので、1フレームに複数回ストローブするゲームでもその時点の入力になる
+func (n *NES) latchPad(port int) PadState {
+	if s := n.movie; s != nil {
+		// 記録を開始したフレームはムービーに含まれないので、開始時の入力のままにする
+		if s.playing || s.frame == 0 || n.padLatched[port] {
+			return n.padStates[port]
+		}
+	}
+
+	p := n.getPads()[port]
+	if p == nil {
+		return 0
+	}
+	if err := p.Load(); err != nil {
+		if n.padErr == nil {
+			n.padErr = xerrors.Errorf("failed to load pad%v: %w", port+1, err)
+		}
+		return n.padStates[port]
+	}
+	n.padStates[port] = readPadState(p, n.TurboRate, n.frameCount)
+
+	if s := n.movie; s != nil {
+		s.movie.Frames[s.frame-1].Pads[port] = n.padStates[port]
+		n.padLatched[port] = true
+	}
+	return n.padStates[port]
+}
+
+// runMovieCommands ... ムービーのコマンドを実行
+func (n *NES) runMovieCommands() {
+	c := n.movieCommands
+	n.movieCommands = 0
+
+	if c&MovieCommandPowerCycle == MovieCommandPowerCycle {
+		n.powerCycle()
+	}
+	if c&MovieCommandSoftReset == MovieCommandSoftReset {
+		n.reset()
+	}
+}
+
+// Run1Cycle ... CPUが1命令実行するまでスケジューラを進める
+func (n *NES) Run1Cycle() error {
+	defer log.Debug(n.Recorder.String())
+
+	n.frameCompleted = false
+	if err := n.scheduler.RunUntil(n.cpuID); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if err := n.padErr; err != nil {
+		n.padErr = nil
+		return xerrors.Errorf(": %w", err)
+	}
+
+	// コンポーネントの実行途中ではスケジューラを作り直せないため、ここで実行する
+	if n.movieCommands != 0 {
+		n.runMovieCommands()
+	}
+
+	if n.frameCompleted {
+		// コンポーネントの実行途中ではスケジューラの状態が確定していないため、ここでスナップショットを保存する
+		if err := n.onFrameCompleted(); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+	return nil
+}
+
+// onFrameCompleted ... 1フレームの描画が完了したときの処理
+func (n *NES) onFrameCompleted() error {
+	n.frameCount++
+
+	if n.rewindBuffer == nil || n.rewinding {
+		return nil
+	}
+	if n.frameCount%n.rewindBuffer.GetInterval() != 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := n.saveState(&buf); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if err := n.rewindBuffer.Push(buf.Bytes()); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// runFrame ... 1フレームの描画が完了するまで実行
+func (n *NES) runFrame() (*Screen, error) {
+	for {
+		if err := n.Run1Cycle(); err != nil {
+			return nil, xerrors.Errorf(": %w", err)
+		}
+		if n.frameCompleted {
+			return n.screen, nil
+		}
+	}
+}
+
+// SetRewinding ... trueの間は通常の実行の代わりに1フレームずつ巻き戻す（エミュレーション実行中でも呼び出せる）
+func (n *NES) SetRewinding(r bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.rewinding = r
+	n.rewindEmpty = false
+	n.cond.Broadcast()
+}
+
+// rewindFrame ... 1つ前のスナップショットに戻して1フレーム描画し直す（戻せるスナップショットがない場合はfalse）
+func (n *NES) rewindFrame() (*Screen, bool, error) {
+	if n.rewindBuffer == nil {
+		return nil, false, nil
+	}
+
+	state, ok, err := n.rewindBuffer.Pop()
+	if err != nil {
+		return nil, false, xerrors.Errorf(": %w", err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	if err := n.loadState(bytes.NewReader(state)); err != nil {
+		return nil, false, xerrors.Errorf(": %w", err)
+	}
+	screen, err := n.runFrame()
+	if err != nil {
+		return nil, false, xerrors.Errorf(": %w", err)
+	}
+	return screen, true, nil
+}
+
+// GetRegion ...
+func (n *NES) GetRegion() Region {
+	return n.region
+}
+
+// Run ... ctxがキャンセルされるか、ウィンドウが閉じられるか、エミュレーションでエラーが発生するまで実行する
+// 終了時はバッテリーバックアップされたRAMを書き出す
+func (n *NES) Run(ctx context.Context) error {
+	n.Renderer.SetFrameRate(n.timing.FrameRate)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// キャンセルされたら一時停止中のエミュレーションも起こして終了させる
+	go func() {
+		<-ctx.Done()
+		n.mu.Lock()
+		n.stopping = true
+		n.cond.Broadcast()
+		n.mu.Unlock()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		err := n.runLoop()
+		if err != nil {
+			log.Warn("error occured")
+			log.Warn("%s", n.Recorder.String())
+			log.Warn("%+v", err)
+		}
+		log.Info("process end")
+
+		// エミュレーションが終了したらウィンドウも閉じる
+		n.Renderer.Close()
+		done <- err
+	}()
+
+	rendererErr := n.Renderer.Run()
+	cancel()
+	emulationErr := <-done
+
+	batteryErr := n.FlushBattery()
+
+	if emulationErr != nil {
+		return xerrors.Errorf(": %w", emulationErr)
+	}
+	if rendererErr != nil {
+		return xerrors.Errorf(": %w", rendererErr)
+	}
+	if batteryErr != nil {
+		return xerrors.Errorf(": %w", batteryErr)
+	}
+	return nil
+}
+
+// runLoop ... 停止するまでフレームを実行し続ける
+func (n *NES) runLoop() error {
+	pacer := &framePacer{}
+	for {
+		n.mu.Lock()
+		if n.shouldWait() {
+			for n.shouldWait() {
+				n.cond.Wait()
 			}
+			pacer.reset()
+		}
+		if n.stopping {
+			n.mu.Unlock()
+			return nil
+		}
+		screen, err := n.step()
+		if err != nil {
+			err = n.handleFault(err)
+		}
+		fps := n.timing.FrameRate * n.speed
+		n.mu.Unlock()
+
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
 
-			err = n.Pad2.Load()
-			if err != nil {
+		// Rendererがフレームの受け渡しで待つ場合があるため、ロックを外してから渡す
+		if screen != nil {
+			if err := n.Renderer.Render(screen); err != nil {
 				return xerrors.Errorf(": %w", err)
 			}
 		}
-	} else {
-		n.ppuDelayCycle = n.ppuDelayCycle - n.cpuBeforeCycle
+
+		pacer.wait(fps)
 	}
+}
 
-	n.Recorder.Cycle = n.Recorder.Cycle + n.cpuBeforeCycle
+// handleFault ... FaultPolicyに従ってエラーを処理する（処理できないエラーはそのまま返す）
+func (n *NES) handleFault(err error) error {
+	if !IsFault(err) {
+		return err
+	}
 
-	cycle, err := n.CPU.Run()
+	switch n.FaultPolicy {
+	case FaultPolicyReset:
+		log.Warn("fault: %v; reset", err)
+		n.reset()
+		return nil
+	case FaultPolicyBreak:
+		log.Warn("fault: %v; break\n%s\n%s", err, n.CPU.String(), n.Recorder.String())
+		n.paused = true
+		n.advanceFrames = 0
+		return nil
+	default:
+		return err
+	}
+}
+
+// FlushBattery ... バッテリーバックアップされたRAMをファイルに書き出す（バッテリーがない場合は何もしない）
+// エミュレーション実行中でも呼び出せる
+func (n *NES) FlushBattery() error {
+	if !n.rom.Header.HasBattery() {
+		return nil
+	}
+
+	n.mu.Lock()
+	ram := append([]byte{}, n.Bus.GetPRGRAM()...)
+	n.mu.Unlock()
+
+	if err := saveBatteryRAM(n.GetBatteryPath(), ram); err != nil {
+		return xerrors.Errorf("failed to save battery: %w", err)
+	}
+	log.Info("battery saved: %v", n.GetBatteryPath())
+	return nil
+}
+
+// GetBatteryPath ... バッテリーバックアップされたRAMのファイルパス
+func (n *NES) GetBatteryPath() string {
+	return strings.TrimSuffix(n.romPath, filepath.Ext(n.romPath)) + ".sav"
+}
+
+// shouldWait ... エミュレーションを止めておくべきか
+func (n *NES) shouldWait() bool {
+	if n.stopping {
+		return false
+	}
+	if n.rewinding {
+		return n.rewindEmpty
+	}
+	return n.paused && n.advanceFrames == 0
+}
+
+// step ... 1フレーム進める（巻き戻し中は1フレーム戻す）
+func (n *NES) step() (*Screen, error) {
+	if n.rewinding {
+		screen, ok, err := n.rewindFrame()
+		if err != nil {
+			return nil, xerrors.Errorf(": %w", err)
+		}
+		if !ok {
+			n.rewindEmpty = true
+		}
+		return screen, nil
+	}
+
+	if n.advanceFrames > 0 {
+		n.advanceFrames--
+	}
+	screen, err := n.runFrame()
 	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return screen, nil
+}
+
+// Pause ... 一時停止する
+func (n *NES) Pause() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.paused = true
+	n.advanceFrames = 0
+}
+
+// Resume ... 一時停止を解除する
+func (n *NES) Resume() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.paused = false
+	n.cond.Broadcast()
+}
+
+// IsPaused ...
+func (n *NES) IsPaused() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.paused
+}
+
+// AdvanceFrame ... 一時停止中に1フレームだけ進める（一時停止していない場合は一時停止する）
+func (n *NES) AdvanceFrame() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.paused {
+		n.paused = true
+		n.advanceFrames = 0
+		return
+	}
+	n.advanceFrames++
+	n.cond.Broadcast()
+}
+
+// SetSpeed ... 実行速度の倍率を設定する（MinSpeed～MaxSpeed、もしくはSpeedUnthrottled）
+func (n *NES) SetSpeed(speed float64) error {
+	if speed != SpeedUnthrottled && (speed < MinSpeed || speed > MaxSpeed) {
+		return xerrors.Errorf("speed is out of range; speed: %v", speed)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.speed = speed
+	return nil
+}
+
+// GetSpeed ...
+func (n *NES) GetSpeed() float64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.speed
+}
+
+// nesState ... セーブステート用の状態
+type nesState struct {
+	Scheduler   SchedulerState
+	WarmUpUntil uint64
+	FrameCount  int // 連射の周期に使う
+	PadStates   [2]PadState
+	PadStates34 [2]PadState // 3P・4P（以前のセーブステートを読めるように1P・2Pと分ける）
+	MovieFrame  int         // 記録・再生中のムービーのフレーム番号（ムービーがない場合は0）
+	PadLatched  [4]bool     // ムービーの記録中に、現在のフレームで入力を読み込んだか
+	OAMDMA      oamDMA      // 転送中のOAM DMA（予約済のイベントは保存しないため作り直す）
+}
+
+// SaveState ... マシン全体の状態を書き出す（エミュレーション実行中でも呼び出せる）
+func (n *NES) SaveState(w io.Writer) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.saveState(w); err != nil {
 		return xerrors.Errorf(": %w", err)
 	}
-	n.cpuBeforeCycle = cycle
+	return nil
+}
 
+// saveState ... n.muを取得済みの呼び出し元から使う
+func (n *NES) saveState(w io.Writer) error {
+	f := &saveStateFile{
+		ROMCRC32: n.rom.CRC32,
+		Region:   n.region,
+	}
+
+	movieFrame := 0
+	if n.movie != nil {
+		movieFrame = n.movie.frame
+	}
+
+	sections := []struct {
+		name    string
+		marshal func() ([]byte, error)
+	}{
+		{"nes", func() ([]byte, error) {
+			return EncodeState(nesState{
+				Scheduler:   n.scheduler.GetState(),
+				WarmUpUntil: n.warmUpUntil,
+				FrameCount:  n.frameCount,
+				PadStates:   [2]PadState{n.padStates[0], n.padStates[1]},
+				PadStates34: [2]PadState{n.padStates[2], n.padStates[3]},
+				MovieFrame:  movieFrame,
+				PadLatched:  n.padLatched,
+				OAMDMA:      n.oamDMA,
+			})
+		}},
+		{"cpu", n.CPU.MarshalState},
+		{"ppu", n.PPU.MarshalState},
+		{"bus", n.Bus.MarshalState},
+		{"vram", func() ([]byte, error) { return EncodeState(n.vram) }},
+	}
+	for _, s := range sections {
+		data, err := s.marshal()
+		if err != nil {
+			return xerrors.Errorf("failed to save %v: %w", s.name, err)
+		}
+		f.Sections = append(f.Sections, saveStateSection{Name: s.name, Data: data})
+	}
+
+	if err := writeSaveState(w, f); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
 	return nil
 }
 
-// Run ...
-func (n *NES) Run() error {
-	go func() {
-		defer func() {
-			log.Info("process end")
-		}()
-		for {
-			if err := n.Run1Cycle(); err != nil {
-				log.Warn("error occured")
-				log.Warn("%s", n.Recorder.String())
-				log.Warn("%+v", err)
-				panic(err)
-			}
+// LoadState ... SaveStateで書き出した状態を読み込む（エミュレーション実行中でも呼び出せる）
+func (n *NES) LoadState(r io.Reader) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.loadState(r); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// loadState ... n.muを取得済みの呼び出し元から使う
+func (n *NES) loadState(r io.Reader) error {
+	f, err := readSaveState(r)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if f.ROMCRC32 != n.rom.CRC32 {
+		return xerrors.Errorf("crc32: %08X, current: %08X: %w", f.ROMCRC32, n.rom.CRC32, ErrStateROMMismatch)
+	}
+
+	// 途中で失敗しても状態が壊れないように、全セクションの存在を確認してから読み込む
+	names := []string{"nes", "cpu", "ppu", "bus", "vram"}
+	data := map[string][]byte{}
+	for _, name := range names {
+		d, err := f.getSection(name)
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
 		}
-	}()
+		data[name] = d
+	}
 
-	if err := n.Renderer.Run(); err != nil {
+	ns := nesState{}
+	if err := DecodeState(data["nes"], &ns); err != nil {
+		return xerrors.Errorf("failed to load nes: %w", err)
+	}
+	vram := &VRAM{}
+	if err := DecodeState(data["vram"], vram); err != nil {
+		return xerrors.Errorf("failed to load vram: %w", err)
+	}
+	if n.movie != nil && ns.MovieFrame > len(n.movie.movie.Frames) {
+		return xerrors.Errorf("state frame: %v, movie frames: %v: %w", ns.MovieFrame, len(n.movie.movie.Frames), ErrStateAfterMovieEnd)
+	}
+
+	if err := n.scheduler.SetState(ns.Scheduler); err != nil {
+		return xerrors.Errorf("failed to load nes: %w", err)
+	}
+	n.warmUpUntil = ns.WarmUpUntil
+	n.frameCount = ns.FrameCount
+	n.padStates = [4]PadState{ns.PadStates[0], ns.PadStates[1], ns.PadStates34[0], ns.PadStates34[1]}
+	n.padLatched = ns.PadLatched
+	n.oamDMA = ns.OAMDMA
+	n.vram.copyFrom(vram)
+
+	if err := n.CPU.UnmarshalState(data["cpu"]); err != nil {
+		return xerrors.Errorf("failed to load cpu: %w", err)
+	}
+	if err := n.PPU.UnmarshalState(data["ppu"]); err != nil {
+		return xerrors.Errorf("failed to load ppu: %w", err)
+	}
+	if err := n.Bus.UnmarshalState(data["bus"]); err != nil {
+		return xerrors.Errorf("failed to load bus: %w", err)
+	}
+
+	n.scheduleWarmUp()
+	n.scheduleOAMDMA()
+	n.seekMovie(ns.MovieFrame)
+	return nil
+}
+
+// seekMovie ... 読み込んだセーブステートのフレームにムービーの位置を合わせる
+// 記録中はそれ以降のフレームを捨てて記録し直す（リレコード）
+func (n *NES) seekMovie(frame int) {
+	s := n.movie
+	if s == nil {
+		return
+	}
+
+	s.frame = frame
+	if s.playing {
+		return
+	}
+	s.movie.Frames = s.movie.Frames[:frame]
+	s.movie.RerecordCount++
+	s.pendingCommands = 0
+}
+
+// GetStateSlotPath ... スロット番号に対応するセーブステートのファイルパス
+func (n *NES) GetStateSlotPath(slot int) string {
+	return fmt.Sprintf("%s.state%d", n.romPath, slot)
+}
+
+// SaveStateSlot ... セーブステートをスロットに保存（エミュレーション実行中でも呼び出せる）
+func (n *NES) SaveStateSlot(slot int) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var buf bytes.Buffer
+	if err := n.saveState(&buf); err != nil {
 		return xerrors.Errorf(": %w", err)
 	}
+	if err := ioutil.WriteFile(n.GetStateSlotPath(slot), buf.Bytes(), 0644); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// LoadStateSlot ... スロットからセーブステートを読み込む（エミュレーション実行中でも呼び出せる）
+func (n *NES) LoadStateSlot(slot int) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
 
+	data, err := ioutil.ReadFile(n.GetStateSlotPath(slot))
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if err := n.loadState(bytes.NewReader(data)); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// warnUnrecordedPorts ... ムービーには標準コントローラー（Multitapを含む）の入力しか記録しないので、それ以外の機器が接続されていれば警告する
+func (n *NES) warnUnrecordedPorts() {
+	if n.Multitap != MultitapNone {
+		return
+	}
+	if n.Expansion != nil {
+		log.Warn("input of expansion device is not recorded in movies: %T", n.Expansion)
+		return
+	}
+	for i, d := range []PortDevice{n.Port1, n.Port2} {
+		if _, ok := d.(*StandardController); !ok {
+			log.Warn("input of port%v device is not recorded in movies: %T", i+1, d)
+		}
+	}
+}
+
+// StartMovieRecording ... ムービーの記録を開始する
+// fromPowerOnがtrueの場合は電源を入れ直してから、falseの場合は現在の状態をセーブステートとして埋め込んで開始する
+func (n *NES) StartMovieRecording(fromPowerOn bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.warnUnrecordedPorts()
+
+	m := &Movie{
+		ROMFilename: strings.TrimSuffix(filepath.Base(n.romPath), filepath.Ext(n.romPath)),
+		ROMChecksum: n.rom.MD5,
+		PAL:         n.region != RegionNTSC,
+		FourScore:   n.Multitap != MultitapNone,
+		Comments:    []string{"author nes-go"},
+		Frames:      []MovieFrame{},
+	}
+
+	n.movie = nil
+	if fromPowerOn {
+		n.powerCycle()
+	} else {
+		var buf bytes.Buffer
+		if err := n.saveState(&buf); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+		m.SaveState = buf.Bytes()
+	}
+
+	n.movie = &movieSession{movie: m, playing: false}
+	n.movieCommands = 0
 	return nil
 }
+
+// PlayMovie ... ムービーを再生する（ROMのチェックサムが異なる場合は警告して再生する）
+func (n *NES) PlayMovie(m *Movie) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.warnUnrecordedPorts()
+
+	if m.ROMChecksum != n.rom.MD5 {
+		log.Warn("movie rom checksum mismatch; movie: %x, rom: %x", m.ROMChecksum, n.rom.MD5)
+	}
+	if m.FourScore != (n.Multitap != MultitapNone) {
+		log.Warn("movie four score mismatch; movie: %v, multitap: %v", m.FourScore, n.Multitap)
+	}
+
+	n.movie = nil
+	if m.SaveState != nil {
+		if err := n.loadState(bytes.NewReader(m.SaveState)); err != nil {
+			return xerrors.Errorf("failed to load the movie save state: %w", err)
+		}
+	} else {
+		n.powerCycle()
+	}
+
+	n.movie = &movieSession{movie: m, playing: true}
+	n.movieCommands = 0
+	return nil
+}
+
+// StopMovie ... ムービーの記録・再生を終了し、そのムービーを返す（記録・再生していない場合はnil）
+func (n *NES) StopMovie() *Movie {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.movie == nil {
+		return nil
+	}
+	m := n.movie.movie
+	n.movie = nil
+	return m
+}
+
+// IsMoviePlaying ...
+func (n *NES) IsMoviePlaying() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.movie != nil && n.movie.playing
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNESLatchPad(t *testing.T) {
+	a := PadState(0x01)
+	b := PadState(0x02)
+
+	type op struct {
+		frame bool      // フレームの先頭の入力の読み込み（NES.loadPads）
+		press *PadState // ホストのパッドで押すボタン
+		latch bool      // ストローブしてコントローラーから8bit読む
+	}
+	frame := op{frame: true}
+	press := func(s PadState) op { return op{press: &s} }
+	latch := op{latch: true}
+
+	tests := []struct {
+		name       string
+		movie      *movieSession
+		ops        []op
+		want       []PadState // latchで読んだ入力
+		wantFrames []PadState // 記録したフレームの1Pの入力
+	}{
+		{
+			name:  "when movie is not used, every latch reads the host pad",
+			movie: nil,
+			ops:   []op{frame, press(a), latch, press(b), latch, latch},
+			want:  []PadState{a, b, b},
+		},
+		{
+			name:       "when recording, the first latch of the frame is recorded and returned until the next frame",
+			movie:      &movieSession{movie: &Movie{}},
+			ops:        []op{frame, press(a), latch, press(b), latch, frame, latch},
+			want:       []PadState{a, a, b},
+			wantFrames: []PadState{a, b},
+		},
+		{
+			name:       "when recording and the frame has no latch, the input at the start of the frame is recorded",
+			movie:      &movieSession{movie: &Movie{}},
+			ops:        []op{press(b), frame, press(a), frame, latch},
+			want:       []PadState{a},
+			wantFrames: []PadState{b, a},
+		},
+		{
+			name:       "when recording has not reached the first frame, the input is not read",
+			movie:      &movieSession{movie: &Movie{}},
+			ops:        []op{press(a), latch, frame, latch},
+			want:       []PadState{0, a},
+			wantFrames: []PadState{a},
+		},
+		{
+			name:       "when playing, recorded input is returned instead of the host pad",
+			movie:      &movieSession{movie: &Movie{Frames: []MovieFrame{{Pads: [4]PadState{a}}, {Pads: [4]PadState{b}}}}, playing: true},
+			ops:        []op{press(b), frame, latch, latch, frame, latch},
+			want:       []PadState{a, a, b},
+			wantFrames: []PadState{a, b},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := &stubPad{pressed: map[ButtonType]bool{}}
+			n := &NES{Pad1: host, Pad2: &stubPad{}, TurboRate: DefaultTurboRate, movie: tt.movie}
+			if err := n.setupPorts(); err != nil {
+				t.Fatalf("failed to setup ports: %v", err)
+			}
+
+			got := []PadState{}
+			for _, o := range tt.ops {
+				switch {
+				case o.frame:
+					if err := n.loadPads(); err != nil {
+						t.Fatalf("failed to load pads: %v", err)
+					}
+				case o.press != nil:
+					host.pressed = map[ButtonType]bool{}
+					for _, s := range padStateBits {
+						host.pressed[s.button] = *o.press&s.bit == s.bit
+					}
+				case o.latch:
+					n.Port1.Strobe(1)
+					n.Port1.Strobe(0)
+					var s PadState
+					for i := uint(0); i < 8; i++ {
+						s = s | PadState(n.Port1.Read())<<i
+					}
+					got = append(got, s)
+				}
+			}
+			if err := n.padErr; err != nil {
+				t.Fatalf("failed to latch: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+			if tt.movie != nil {
+				frames := []PadState{}
+				for _, f := range tt.movie.movie.Frames {
+					frames = append(frames, f.Pads[0])
+				}
+				if !reflect.DeepEqual(frames, tt.wantFrames) {
+					t.Errorf("wrong frames\nwant: %#v\ngot: %#v", tt.wantFrames, frames)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package domain
+
+import "golang.org/x/xerrors"
+
+const (
+	// oamDMABytes ... OAM DMAで転送するバイト数（1ページ）
+	oamDMABytes = 256
+	// oamDMAStallCycle ... OAM DMAでCPUが停止するサイクル数（書き込みを待つ1サイクルと、256回の読み込みと書き込み）
+	// 奇数サイクルから始まる場合は読み込みのサイクルに揃えるために1サイクル増える
+	oamDMAStallCycle = 1 + oamDMABytes*2
+)
+
+// oamDMA ... 転送中のOAM DMA（セーブステートに含める）
+type oamDMA struct {
+	Active bool
+	Page   byte   // 転送元のページ（$xx00-$xxFF）
+	Index  int    // 次に転送するバイト
+	Clock  uint64 // 次のバイトを$2004に書き込むマスタークロック
+}
+
+// catchUp ... CPUが命令の先頭からcycleサイクル目にPPUのレジスタなどにアクセスする前に、その時刻までPPUとイベントを進める
+func (n *NES) catchUp(cycle int) error {
+	if err := n.scheduler.CatchUp(n.cpuID, cycle); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// startOAMDMA ... $4014への書き込みで、次のサイクルからCPUを止めて1ページ分を$2004に転送する
+// 書き込む前にcatchUpしているため、スケジューラの現在時刻は$4014に書き込んだサイクルになっている
+func (n *NES) startOAMDMA(page byte) error {
+	divider := uint64(n.timing.CPUDivider)
+	start := n.scheduler.GetClock()/divider + 1 // DMAが始まるCPUサイクル
+
+	stall := oamDMAStallCycle
+	if start%2 == 1 {
+		stall++
+	}
+	n.scheduler.Stall(n.cpuID, stall)
+
+	// 最後の512サイクルで読み込みと書き込みを交互に行う
+	first := start + uint64(stall-oamDMABytes*2) + 1
+	n.oamDMA = oamDMA{
+		Active: true,
+		Page:   page,
+		Index:  0,
+		Clock:  first * divider,
+	}
+	n.scheduleOAMDMA()
+	return nil
+}
+
+// scheduleOAMDMA ... 転送中のOAM DMAで次のバイトを書き込むイベントを予約
+func (n *NES) scheduleOAMDMA() {
+	if !n.oamDMA.Active {
+		return
+	}
+
+	var delay uint64
+	if clock := n.scheduler.GetClock(); n.oamDMA.Clock > clock {
+		delay = n.oamDMA.Clock - clock
+	}
+	n.scheduler.ScheduleEvent("OAM DMA", delay, n.transferOAMDMA)
+}
+
+// transferOAMDMA ... 1バイト読み込んで$2004に書き込み、次のバイトを予約する
+// CPUは止まっているため、読み込みは書き込みのサイクルでまとめて行う
+func (n *NES) transferOAMDMA() error {
+	d := &n.oamDMA
+	addr := Address(uint16(d.Page)<<8 | uint16(d.Index))
+
+	data, err := n.Bus.ReadByCPU(addr)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if err := n.Bus.WriteByCPU(0x2004, data); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	d.Index++
+	if d.Index >= oamDMABytes {
+		d.Active = false
+		return nil
+	}
+	d.Clock = d.Clock + 2*uint64(n.timing.CPUDivider)
+	n.scheduleOAMDMA()
+	return nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const (
+	// SpeedUnthrottled ... 速度制限なし
+	SpeedUnthrottled = 0
+	// MinSpeed ... 最低速度（倍率）
+	MinSpeed = 0.25
+	// MaxSpeed ... 最高速度（倍率）
+	MaxSpeed = 8
+
+	// maxPacerDelay ... これ以上遅れた場合は追いつこうとせずに基準時刻をリセットする
+	maxPacerDelay = 100 * time.Millisecond
+)
+
+// SpeedSteps ... ホットキーで切り替える速度（倍率）
+var SpeedSteps = []float64{0.25, 0.5, 1, 2, 4, 8}
+
+// framePacer ... フレームレートに合わせて待機する
+type framePacer struct {
+	next time.Time // 次のフレームを開始する時刻
+}
+
+// reset ... 一時停止などで待機した後に呼び出す
+func (p *framePacer) reset() {
+	p.next = time.Time{}
+}
+
+// wait ... 1フレーム分の時間が経過するまで待機する（fpsが0以下の場合は待機しない）
+func (p *framePacer) wait(fps float64) {
+	if fps <= 0 {
+		p.reset()
+		return
+	}
+
+	now := time.Now()
+	if p.next.IsZero() || now.Sub(p.next) > maxPacerDelay {
+		p.next = now
+	}
+	p.next = p.next.Add(time.Duration(float64(time.Second) / fps))
+
+	if d := p.next.Sub(now); d > 0 {
+		time.Sleep(d)
+	}
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"sync"
+	"testing"
+)
+
+// newPacedNES ... CPU・PPUを接続せずに一時停止・速度の状態だけを扱うNESを生成
+func newPacedNES() *NES {
+	n := &NES{speed: 1}
+	n.cond = sync.NewCond(&n.mu)
+	return n
+}
+
+func TestNESSetSpeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		speed   float64
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:  "when speed is min speed, it is accepted",
+			speed: 0.25,
+			want:  0.25,
+		},
+		{
+			name:  "when speed is max speed, it is accepted",
+			speed: 8,
+			want:  8,
+		},
+		{
+			name:  "when speed is unthrottled, it is accepted",
+			speed: SpeedUnthrottled,
+			want:  SpeedUnthrottled,
+		},
+		{
+			name:    "when speed is lower than min speed, return error and keep speed",
+			speed:   0.1,
+			want:    1,
+			wantErr: true,
+		},
+		{
+			name:    "when speed is higher than max speed, return error and keep speed",
+			speed:   9,
+			want:    1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := newPacedNES()
+
+			err := n.SetSpeed(tt.speed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wrong error\nwantErr: %#v\ngot: %#v", tt.wantErr, err)
+			}
+			if got := n.GetSpeed(); got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNESAdvanceFrame(t *testing.T) {
+	tests := []struct {
+		name              string
+		paused            bool
+		wantPaused        bool
+		wantAdvanceFrames int
+		wantWait          bool
+	}{
+		{
+			name:              "when running, pause without advancing",
+			paused:            false,
+			wantPaused:        true,
+			wantAdvanceFrames: 0,
+			wantWait:          true,
+		},
+		{
+			name:              "when paused, advance one frame and keep paused",
+			paused:            true,
+			wantPaused:        true,
+			wantAdvanceFrames: 1,
+			wantWait:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := newPacedNES()
+			if tt.paused {
+				n.Pause()
+			}
+
+			n.AdvanceFrame()
+
+			if got := n.IsPaused(); got != tt.wantPaused {
+				t.Errorf("wrong paused\nwant: %#v\ngot: %#v", tt.wantPaused, got)
+			}
+			if n.advanceFrames != tt.wantAdvanceFrames {
+				t.Errorf("wrong advance frames\nwant: %#v\ngot: %#v", tt.wantAdvanceFrames, n.advanceFrames)
+			}
+			if got := n.shouldWait(); got != tt.wantWait {
+				t.Errorf("wrong wait\nwant: %#v\ngot: %#v", tt.wantWait, got)
+			}
+		})
+	}
+}
+
+func TestNESShouldWaitRewinding(t *testing.T) {
+	tests := []struct {
+		name         string
+		rewindBuffer *RewindBuffer
+		paused       bool
+		want         bool
+	}{
+		{
+			name:         "when rewind buffer is empty, wait after the first rewind step",
+			rewindBuffer: NewRewindBuffer(RewindConfig{}),
+			want:         true,
+		},
+		{
+			name:         "when rewind is disabled, wait after the first rewind step",
+			rewindBuffer: nil,
+			want:         true,
+		},
+		{
+			name:         "when paused and rewind buffer is empty, wait after the first rewind step",
+			rewindBuffer: NewRewindBuffer(RewindConfig{}),
+			paused:       true,
+			want:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := newPacedNES()
+			n.rewindBuffer = tt.rewindBuffer
+			n.paused = tt.paused
+
+			n.SetRewinding(true)
+			if n.shouldWait() {
+				t.Fatalf("wait before the rewind step")
+			}
+
+			screen, err := n.step()
+			if err != nil {
+				t.Fatalf("failed to step: %v", err)
+			}
+			if screen != nil {
+				t.Errorf("wrong screen\nwant: %#v\ngot: %#v", nil, screen)
+			}
+			if got := n.shouldWait(); got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+
+			// 巻き戻しをやめたら一時停止していなければ再開する
+			n.SetRewinding(false)
+			if got := n.shouldWait(); got != tt.paused {
+				t.Errorf("wrong output after rewinding\nwant: %#v\ngot: %#v", tt.paused, got)
+			}
+		})
+	}
+}
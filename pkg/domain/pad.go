@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
 // ButtonType ...
 type ButtonType string
 
@@ -12,8 +18,14 @@ const (
 	ButtonTypeDown   ButtonType = ButtonType("DOWN")
 	ButtonTypeLeft   ButtonType = ButtonType("LEFT")
 	ButtonTypeRight  ButtonType = ButtonType("RIGHT")
+
+	// ButtonTypeTurboA ... 押している間、Aを連射する（仮想的なボタン）
+	ButtonTypeTurboA ButtonType = ButtonType("TURBO_A")
+	// ButtonTypeTurboB ... 押している間、Bを連射する（仮想的なボタン）
+	ButtonTypeTurboB ButtonType = ButtonType("TURBO_B")
 )
 
+// ButtonList ... Padが扱うボタン（連射ボタンを含む）
 var ButtonList []ButtonType = []ButtonType{
 	ButtonTypeA,
 	ButtonTypeB,
@@ -23,6 +35,18 @@ var ButtonList []ButtonType = []ButtonType{
 	ButtonTypeDown,
 	ButtonTypeLeft,
 	ButtonTypeRight,
+	ButtonTypeTurboA,
+	ButtonTypeTurboB,
+}
+
+// ParseButtonType ... ボタン名（大文字小文字は区別しない）からButtonTypeを取得
+func ParseButtonType(name string) (ButtonType, error) {
+	for _, b := range ButtonList {
+		if strings.EqualFold(name, string(b)) {
+			return b, nil
+		}
+	}
+	return "", xerrors.Errorf("unknown button: %v", name)
 }
 
 // Pad ...
@@ -30,3 +54,36 @@ type Pad interface {
 	Load() error
 	IsPressed(ButtonType) bool
 }
+
+// CombinedPad ... 複数の入力（キーボードとゲームパッドなど）を1つのパッドとして扱う
+// どれか1つでも押されていればボタンを押したことにする
+type CombinedPad struct {
+	pads []Pad
+}
+
+// NewCombinedPad ...
+func NewCombinedPad(pads ...Pad) *CombinedPad {
+	return &CombinedPad{
+		pads: pads,
+	}
+}
+
+// Load ...
+func (c *CombinedPad) Load() error {
+	for _, p := range c.pads {
+		if err := p.Load(); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+	return nil
+}
+
+// IsPressed ...
+func (c *CombinedPad) IsPressed(b ButtonType) bool {
+	for _, p := range c.pads {
+		if p.IsPressed(b) {
+			return true
+		}
+	}
+	return false
+}
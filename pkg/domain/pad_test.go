@@ -0,0 +1,62 @@
+package domain
+
+import "testing"
+
+// stubPad ... 指定したボタンだけ押されているパッド
+type stubPad struct {
+	pressed map[ButtonType]bool
+	loaded  int
+}
+
+func (p *stubPad) Load() error {
+	p.loaded++
+	return nil
+}
+
+func (p *stubPad) IsPressed(b ButtonType) bool {
+	return p.pressed[b]
+}
+
+func TestCombinedPad(t *testing.T) {
+	keyboard := &stubPad{pressed: map[ButtonType]bool{ButtonTypeA: true}}
+	gamepad := &stubPad{pressed: map[ButtonType]bool{ButtonTypeLeft: true}}
+	pad := NewCombinedPad(keyboard, gamepad)
+
+	if err := pad.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if keyboard.loaded != 1 || gamepad.loaded != 1 {
+		t.Errorf("wrong load count\nwant: (1, 1)\ngot: (%v, %v)", keyboard.loaded, gamepad.loaded)
+	}
+
+	tests := []struct {
+		name   string
+		button ButtonType
+		want   bool
+	}{
+		{
+			name:   "when first pad is pressed, return true",
+			button: ButtonTypeA,
+			want:   true,
+		},
+		{
+			name:   "when second pad is pressed, return true",
+			button: ButtonTypeLeft,
+			want:   true,
+		},
+		{
+			name:   "when no pad is pressed, return false",
+			button: ButtonTypeStart,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pad.IsPressed(tt.button)
+			if got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
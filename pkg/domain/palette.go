@@ -1,5 +1,12 @@
 package domain
 
+import (
+	"io"
+	"io/ioutil"
+
+	"golang.org/x/xerrors"
+)
+
 // Palette ...
 type Palette []byte
 
@@ -15,3 +22,68 @@ func (p *Palette) GetColor(no uint8) (uint8, uint8, uint8) {
 	c := colors[index]
 	return c[0], c[1], c[2]
 }
+
+// GetColorIndex ... システムパレット上の色番号
+func (p *Palette) GetColorIndex(no uint8) uint8 {
+	return (*p)[no] & 0x3F
+}
+
+const (
+	// EmphasizeRed ... 赤を強調（PPUMASKのbit5）
+	EmphasizeRed = 0x01
+	// EmphasizeGreen ... 緑を強調（PPUMASKのbit6）
+	EmphasizeGreen = 0x02
+	// EmphasizeBlue ... 青を強調（PPUMASKのbit7）
+	EmphasizeBlue = 0x04
+
+	// emphasisAttenuation ... 強調していない色成分の減衰率
+	emphasisAttenuation = 0.816
+)
+
+// GetSystemColor ... システムパレットの色番号から色を取得
+// emphasisが指定されている場合は、強調していない色成分を減衰させる
+func GetSystemColor(index uint8, emphasis uint8) (uint8, uint8, uint8) {
+	c := colors[index&0x3F]
+	if emphasis == 0 {
+		return c[0], c[1], c[2]
+	}
+
+	r, g, b := float64(c[0]), float64(c[1]), float64(c[2])
+	if (emphasis & EmphasizeRed) == EmphasizeRed {
+		g, b = g*emphasisAttenuation, b*emphasisAttenuation
+	}
+	if (emphasis & EmphasizeGreen) == EmphasizeGreen {
+		r, b = r*emphasisAttenuation, b*emphasisAttenuation
+	}
+	if (emphasis & EmphasizeBlue) == EmphasizeBlue {
+		r, g = r*emphasisAttenuation, g*emphasisAttenuation
+	}
+	return uint8(r), uint8(g), uint8(b)
+}
+
+const (
+	// systemPaletteSize ... .palファイルの64色分のサイズ
+	systemPaletteSize = 64 * 3
+	// systemPaletteWithEmphasisSize ... 強調ビットの組み合わせ（8通り）ごとの色を含む.palファイルのサイズ
+	systemPaletteWithEmphasisSize = systemPaletteSize * 8
+)
+
+// LoadSystemPalette ... .palファイル（RGBの順に64色分）からシステムパレットを読み込む
+// 強調ビットごとの色を含む512色分のファイルの場合は先頭の64色を使う
+// エミュレーションを開始する前に呼び出すこと
+func LoadSystemPalette(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if len(data) != systemPaletteSize && len(data) != systemPaletteWithEmphasisSize {
+		return xerrors.Errorf("invalid palette size; want: %v or %v, got: %v", systemPaletteSize, systemPaletteWithEmphasisSize, len(data))
+	}
+
+	c := make([][]byte, 64)
+	for i := range c {
+		c[i] = []byte{data[i*3], data[i*3+1], data[i*3+2]}
+	}
+	colors = c
+	return nil
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGetSystemColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    uint8
+		emphasis uint8
+		wantR    uint8
+		wantG    uint8
+		wantB    uint8
+	}{
+		{
+			name:     "when emphasis is none, return original color",
+			index:    0x30,
+			emphasis: 0,
+			wantR:    0xFF,
+			wantG:    0xFF,
+			wantB:    0xFF,
+		},
+		{
+			name:     "when red is emphasized, attenuate green and blue",
+			index:    0x30,
+			emphasis: EmphasizeRed,
+			wantR:    0xFF,
+			wantG:    0xD0,
+			wantB:    0xD0,
+		},
+		{
+			name:     "when all colors are emphasized, attenuate all colors",
+			index:    0x30,
+			emphasis: EmphasizeRed | EmphasizeGreen | EmphasizeBlue,
+			wantR:    0xA9,
+			wantG:    0xA9,
+			wantB:    0xA9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, g, b := GetSystemColor(tt.index, tt.emphasis)
+			if r != tt.wantR || g != tt.wantG || b != tt.wantB {
+				t.Errorf("wrong output\nwant: (%#v, %#v, %#v)\ngot: (%#v, %#v, %#v)", tt.wantR, tt.wantG, tt.wantB, r, g, b)
+			}
+		})
+	}
+}
+
+func TestLoadSystemPalette(t *testing.T) {
+	original := colors
+	defer func() { colors = original }()
+
+	tests := []struct {
+		name    string
+		size    int
+		wantErr bool
+	}{
+		{
+			name: "when file has 64 colors, load them",
+			size: 64 * 3,
+		},
+		{
+			name: "when file has 512 colors, load the first 64 colors",
+			size: 512 * 3,
+		},
+		{
+			name:    "when file size is invalid, return error",
+			size:    63 * 3,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			colors = original
+
+			data := make([]byte, tt.size)
+			for i := range data {
+				data[i] = byte(i)
+			}
+
+			err := LoadSystemPalette(bytes.NewReader(data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wrong error\nwantErr: %#v\ngot: %#v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			r, g, b := GetSystemColor(0x3F, 0)
+			if r != 0xBD || g != 0xBE || b != 0xBF {
+				t.Errorf("wrong output\nwant: (%#v, %#v, %#v)\ngot: (%#v, %#v, %#v)", 0xBD, 0xBE, 0xBF, r, g, b)
+			}
+		})
+	}
+}
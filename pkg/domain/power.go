@@ -0,0 +1,85 @@
+package domain
+
+import "math/rand"
+
+// RAMFill ... 電源投入時のRAMの初期値
+type RAMFill int
+
+const (
+	// RAMFillZero ... 0x00で埋める
+	RAMFillZero RAMFill = iota
+	// RAMFillFF ... 0xFFで埋める
+	RAMFillFF
+	// RAMFillRandom ... シードを元にした乱数で埋める（同じシードなら同じ内容になる）
+	RAMFillRandom
+)
+
+const (
+	// DefaultPPUWarmUpCycles ... 電源投入後にPPUが一部のレジスタへの書き込みを無視するサイクル数（CPUサイクル）
+	// 仕様: https://wiki.nesdev.com/w/index.php/PPU_power_up_state
+	DefaultPPUWarmUpCycles = 29658
+
+	// IOSize ... I/Oポート（0x4000-0x401F）のバイト数
+	IOSize = 0x0020
+)
+
+// PowerOnProfile ... 電源投入時の状態
+// 仕様: https://wiki.nesdev.com/w/index.php/CPU_power_up_state
+type PowerOnProfile struct {
+	RAMFill RAMFill
+	RAMSeed int64 // RAMFillRandomの場合のシード
+
+	// リセットシーケンス前のCPUレジスタ（リセットシーケンスでSは3減り、Iがセットされる）
+	A byte
+	X byte
+	Y byte
+	S byte
+	P byte
+
+	PPUWarmUpCycles int // 0の場合はウォームアップなし
+
+	IO [IOSize]byte // I/Oポート（0x4000-0x401F）の初期値（APUを実装するまでは、読み出すとこの値を返す）
+}
+
+// DefaultPowerOnProfile ...
+func DefaultPowerOnProfile() PowerOnProfile {
+	return PowerOnProfile{
+		RAMFill:         RAMFillZero,
+		RAMSeed:         0,
+		A:               0x00,
+		X:               0x00,
+		Y:               0x00,
+		S:               0x00,
+		P:               0x04,
+		PPUWarmUpCycles: DefaultPPUWarmUpCycles,
+		IO:              defaultIO(),
+	}
+}
+
+// defaultIO ... nestest.logに合わせて、矩形波2ch（0x4004-0x4007）とAPUステータス（0x4015）は0xFFにする
+func defaultIO() [IOSize]byte {
+	io := [IOSize]byte{}
+	io[0x04] = 0xFF
+	io[0x05] = 0xFF
+	io[0x06] = 0xFF
+	io[0x07] = 0xFF
+	io[0x15] = 0xFF
+	return io
+}
+
+// FillRAM ... RAMFillに従ってramを埋める
+func (p PowerOnProfile) FillRAM(ram []byte) {
+	switch p.RAMFill {
+	case RAMFillFF:
+		for i := range ram {
+			ram[i] = 0xFF
+		}
+	case RAMFillRandom:
+		r := rand.New(rand.NewSource(p.RAMSeed))
+		r.Read(ram)
+	default:
+		for i := range ram {
+			ram[i] = 0x00
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPowerOnProfileFillRAM(t *testing.T) {
+	tests := []struct {
+		name string
+		fill RAMFill
+		want byte
+	}{
+		{
+			name: "when fill is zero, RAM is filled with 0x00",
+			fill: RAMFillZero,
+			want: 0x00,
+		},
+		{
+			name: "when fill is FF, RAM is filled with 0xFF",
+			fill: RAMFillFF,
+			want: 0xFF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ram := []byte{0x12, 0x34, 0x56, 0x78}
+			PowerOnProfile{RAMFill: tt.fill}.FillRAM(ram)
+
+			want := bytes.Repeat([]byte{tt.want}, len(ram))
+			if !bytes.Equal(ram, want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, ram)
+			}
+		})
+	}
+
+	t.Run("when fill is random, the same seed makes the same RAM", func(t *testing.T) {
+		p := PowerOnProfile{RAMFill: RAMFillRandom, RAMSeed: 42}
+		ram1 := make([]byte, 0x0800)
+		ram2 := make([]byte, 0x0800)
+		p.FillRAM(ram1)
+		p.FillRAM(ram2)
+
+		if !bytes.Equal(ram1, ram2) {
+			t.Errorf("wrong output\nwant: %#v\ngot: %#v", ram1[:16], ram2[:16])
+		}
+		if bytes.Equal(ram1, make([]byte, 0x0800)) {
+			t.Errorf("RAM is not filled")
+		}
+	})
+}
+
+func TestDefaultPowerOnProfileIO(t *testing.T) {
+	tests := []struct {
+		name string
+		addr Address
+		want byte
+	}{
+		{
+			name: "when address is pulse 1, 0x00 is read",
+			addr: 0x4000,
+			want: 0x00,
+		},
+		{
+			name: "when address is the first register of pulse 2, 0xFF is read",
+			addr: 0x4004,
+			want: 0xFF,
+		},
+		{
+			name: "when address is the last register of pulse 2, 0xFF is read",
+			addr: 0x4007,
+			want: 0xFF,
+		},
+		{
+			name: "when address is triangle, 0x00 is read",
+			addr: 0x4008,
+			want: 0x00,
+		},
+		{
+			name: "when address is APU status, 0xFF is read",
+			addr: 0x4015,
+			want: 0xFF,
+		},
+		{
+			name: "when address is the last I/O port, 0x00 is read",
+			addr: 0x401F,
+			want: 0x00,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultPowerOnProfile().IO[tt.addr-0x4000]
+			if got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
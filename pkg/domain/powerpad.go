@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// PowerPadSide ... Power Pad（ファミリートレーナー）のマットの面
+type PowerPadSide int
+
+const (
+	// PowerPadSideA ... 8個のボタンの面
+	PowerPadSideA PowerPadSide = iota
+	// PowerPadSideB ... 12個のボタンの面
+	PowerPadSideB
+)
+
+// String ...
+func (s PowerPadSide) String() string {
+	switch s {
+	case PowerPadSideA:
+		return "A"
+	case PowerPadSideB:
+		return "B"
+	default:
+		return "unknown"
+	}
+}
+
+// GetButtonNum ... 面のボタン数
+func (s PowerPadSide) GetButtonNum() int {
+	if s == PowerPadSideA {
+		return len(powerPadSideA)
+	}
+	return powerPadSwitches
+}
+
+// ParsePowerPadSide ... a/bからPowerPadSideを取得
+func ParsePowerPadSide(s string) (PowerPadSide, error) {
+	for _, side := range []PowerPadSide{PowerPadSideA, PowerPadSideB} {
+		if strings.EqualFold(s, side.String()) {
+			return side, nil
+		}
+	}
+	return PowerPadSideB, xerrors.Errorf("unknown power pad side: %v", s)
+}
+
+// powerPadSwitches ... マットのスイッチの数（B面のボタンの番号で1-12）
+const powerPadSwitches = 12
+
+// powerPadSideA ... A面のボタン（1-8）が押すB面のスイッチ
+// A面はB面の裏側なので、左右が反転する
+//
+//	A面:  . 1 2 .    B面:  1  2  3  4
+//	      3 4 5 6          5  6  7  8
+//	      . 7 8 .          9 10 11 12
+var powerPadSideA = [8]int{3, 2, 8, 7, 6, 5, 11, 10}
+
+var (
+	// powerPadLowOrder ... D3の8bitのシフトレジスタから読むスイッチの順
+	powerPadLowOrder = [8]int{2, 1, 5, 9, 6, 10, 11, 7}
+	// powerPadHighOrder ... D4の4bitのシフトレジスタから読むスイッチの順（5回目以降は1）
+	powerPadHighOrder = [4]int{4, 3, 12, 8}
+)
+
+// PowerPad ... Power Pad（2Pのポートに接続する）
+// ストローブでスイッチの状態を2つのシフトレジスタに読み込み、1回読むごとにD3・D4に1bitずつ送り出す（押している場合は1）
+// 仕様: https://wiki.nesdev.com/w/index.php/Power_Pad
+type PowerPad struct {
+	input  PowerPadInput
+	side   PowerPadSide
+	strobe bool
+	low    byte
+	high   byte
+}
+
+// NewPowerPad ...
+func NewPowerPad(input PowerPadInput, side PowerPadSide) *PowerPad {
+	return &PowerPad{
+		input: input,
+		side:  side,
+	}
+}
+
+// getSwitches ... 押しているスイッチ（B面のボタンの番号）
+func (p *PowerPad) getSwitches() [powerPadSwitches + 1]bool {
+	switches := [powerPadSwitches + 1]bool{}
+	for b := 1; b <= p.side.GetButtonNum(); b++ {
+		if !p.input.IsButtonPressed(b) {
+			continue
+		}
+		if p.side == PowerPadSideA {
+			switches[powerPadSideA[b-1]] = true
+		} else {
+			switches[b] = true
+		}
+	}
+	return switches
+}
+
+// getRegisters ... スイッチの状態を読み出し順に並べたD3・D4のシフトレジスタの値
+func (p *PowerPad) getRegisters() (byte, byte) {
+	switches := p.getSwitches()
+	low := byte(0x00)
+	for i, s := range powerPadLowOrder {
+		if switches[s] {
+			low = low | 1<<uint(i)
+		}
+	}
+	high := byte(0xF0)
+	for i, s := range powerPadHighOrder {
+		if switches[s] {
+			high = high | 1<<uint(i)
+		}
+	}
+	return low, high
+}
+
+// latch ... スイッチの状態をシフトレジスタに読み込む
+func (p *PowerPad) latch() {
+	p.low, p.high = p.getRegisters()
+}
+
+// Load ...
+func (p *PowerPad) Load() error {
+	return p.input.Load()
+}
+
+// Strobe ...
+func (p *PowerPad) Strobe(out byte) {
+	p.strobe = out&PortStrobe == PortStrobe
+	if p.strobe {
+		p.latch()
+	}
+}
+
+// Read ... 読み終わったビットは1で埋める
+func (p *PowerPad) Read() byte {
+	if p.strobe {
+		p.latch()
+		return p.Peek()
+	}
+
+	d := p.Peek()
+	p.low = p.low>>1 | 0x80
+	p.high = p.high>>1 | 0x80
+	return d
+}
+
+// Peek ...
+func (p *PowerPad) Peek() byte {
+	low, high := p.low, p.high
+	if p.strobe {
+		low, high = p.getRegisters()
+	}
+	return (low&0x01)<<3 | (high&0x01)<<4
+}
+
+// powerPadState ... セーブステート用の状態
+type powerPadState struct {
+	Strobe bool
+	Low    byte
+	High   byte
+}
+
+// MarshalState ...
+func (p *PowerPad) MarshalState() ([]byte, error) {
+	data, err := EncodeState(powerPadState{Strobe: p.strobe, Low: p.low, High: p.high})
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (p *PowerPad) UnmarshalState(data []byte) error {
+	s := powerPadState{}
+	if err := DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	p.strobe = s.Strobe
+	p.low = s.Low
+	p.high = s.High
+	return nil
+}
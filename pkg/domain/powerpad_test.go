@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// stubPowerPadInput ... 指定したボタンだけ押されているPower Pad
+type stubPowerPadInput struct {
+	pressed map[int]bool
+}
+
+func (i *stubPowerPadInput) Load() error {
+	return nil
+}
+
+func (i *stubPowerPadInput) IsButtonPressed(button int) bool {
+	return i.pressed[button]
+}
+
+func TestPowerPad(t *testing.T) {
+	tests := []struct {
+		name    string
+		side    PowerPadSide
+		pressed map[int]bool
+		want    []byte
+	}{
+		{
+			name:    "when nothing is pressed, D4 returns 1 after 4 reads and D3 after 8 reads",
+			side:    PowerPadSideB,
+			pressed: map[int]bool{},
+			want:    []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x10, 0x10, 0x10, 0x18},
+		},
+		{
+			name:    "when side B buttons 1, 7 and 12 are pressed, send them in serial order",
+			side:    PowerPadSideB,
+			pressed: map[int]bool{1: true, 7: true, 12: true},
+			want:    []byte{0x00, 0x08, 0x10, 0x00, 0x10, 0x10, 0x10, 0x18, 0x18},
+		},
+		{
+			name:    "when side A button 1 is pressed, press switch 3 of side B",
+			side:    PowerPadSideA,
+			pressed: map[int]bool{1: true},
+			want:    []byte{0x00, 0x10, 0x00, 0x00, 0x10, 0x10, 0x10, 0x10, 0x18},
+		},
+		{
+			name:    "when side A is used, buttons beyond 8 are ignored",
+			side:    PowerPadSideA,
+			pressed: map[int]bool{9: true, 12: true},
+			want:    []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x10, 0x10, 0x10, 0x18},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readPort(NewPowerPad(&stubPowerPadInput{pressed: tt.pressed}, tt.side), len(tt.want))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
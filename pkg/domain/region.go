@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Region ... 地域（テレビ方式）
+type Region int
+
+const (
+	// RegionNTSC ... 北米・日本
+	RegionNTSC Region = iota
+	// RegionPAL ... 欧州・豪州
+	RegionPAL
+	// RegionDendy ... ロシア等の互換機
+	RegionDendy
+)
+
+// String ...
+func (r Region) String() string {
+	switch r {
+	case RegionNTSC:
+		return "NTSC"
+	case RegionPAL:
+		return "PAL"
+	case RegionDendy:
+		return "Dendy"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseRegion ... ntsc/pal/dendyからRegionを取得
+func ParseRegion(s string) (Region, error) {
+	for _, r := range []Region{RegionNTSC, RegionPAL, RegionDendy} {
+		if strings.EqualFold(s, r.String()) {
+			return r, nil
+		}
+	}
+	return RegionNTSC, xerrors.Errorf("unknown region: %v", s)
+}
+
+// RegionTiming ... 地域ごとのタイミング
+// 仕様: https://wiki.nesdev.com/w/index.php/Cycle_reference_chart
+type RegionTiming struct {
+	MasterClock     float64 // マスタークロック(Hz)
+	CPUDivider      int     // CPUクロックの分周比
+	PPUDivider      int     // PPUクロックの分周比
+	Scanlines       uint16  // 1フレームのスキャンライン数（pre-render lineを含む）
+	VBlankScanline  uint16  // VBlankが始まるスキャンライン
+	FrameRate       float64 // フレームレート(fps)
+	SkipOddFrameDot bool    // 奇数フレームでpre-render lineの最終dotをスキップするか
+	SwapEmphasisRG  bool    // PPUMASKの赤と緑の強調ビットが入れ替わっているか
+}
+
+var regionTimings = map[Region]RegionTiming{
+	RegionNTSC: {
+		MasterClock:     21477272,
+		CPUDivider:      12,
+		PPUDivider:      4,
+		Scanlines:       262,
+		VBlankScanline:  241,
+		FrameRate:       60.0988,
+		SkipOddFrameDot: true,
+		SwapEmphasisRG:  false,
+	},
+	RegionPAL: {
+		MasterClock:     26601712,
+		CPUDivider:      16,
+		PPUDivider:      5,
+		Scanlines:       312,
+		VBlankScanline:  241,
+		FrameRate:       50.007,
+		SkipOddFrameDot: false,
+		SwapEmphasisRG:  true,
+	},
+	RegionDendy: {
+		MasterClock:     26601712,
+		CPUDivider:      15,
+		PPUDivider:      5,
+		Scanlines:       312,
+		VBlankScanline:  291,
+		FrameRate:       50.007,
+		SkipOddFrameDot: false,
+		SwapEmphasisRG:  true,
+	},
+}
+
+// GetTiming ...
+func (r Region) GetTiming() RegionTiming {
+	if t, ok := regionTimings[r]; ok {
+		return t
+	}
+	return regionTimings[RegionNTSC]
+}
+
+// GetPreRenderScanline ... pre-render lineのスキャンライン
+func (t RegionTiming) GetPreRenderScanline() uint16 {
+	return t.Scanlines - 1
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io/ioutil"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// DefaultRewindInterval ... スナップショットを保存する間隔（フレーム数）
+	DefaultRewindInterval = 2
+	// DefaultRewindMaxBytes ... 巻き戻し用のバッファが使用する最大メモリ（バイト）
+	DefaultRewindMaxBytes = 32 * 1024 * 1024
+)
+
+// RewindConfig ... 巻き戻しの設定
+type RewindConfig struct {
+	Interval int // スナップショットを保存する間隔（フレーム数）
+	MaxBytes int // バッファが使用する最大メモリ（バイト）、超えた場合は古いものから破棄する
+}
+
+// RewindBuffer ... 巻き戻し用のスナップショットのリングバッファ
+// 最新のスナップショットだけをそのまま保持し、それより古いものは1つ新しいスナップショットとの差分（XOR）を圧縮して保持する
+type RewindBuffer struct {
+	config RewindConfig
+
+	latest    []byte   // 最新のスナップショット
+	deltas    [][]byte // 古い順（deltas[i]はi番目とi+1番目（最後はlatest）の差分）
+	usedBytes int
+}
+
+// NewRewindBuffer ...
+func NewRewindBuffer(c RewindConfig) *RewindBuffer {
+	if c.Interval <= 0 {
+		c.Interval = DefaultRewindInterval
+	}
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = DefaultRewindMaxBytes
+	}
+	return &RewindBuffer{
+		config: c,
+		deltas: [][]byte{},
+	}
+}
+
+// GetInterval ...
+func (b *RewindBuffer) GetInterval() int {
+	return b.config.Interval
+}
+
+// Len ... 保持しているスナップショット数
+func (b *RewindBuffer) Len() int {
+	if b.latest == nil {
+		return 0
+	}
+	return len(b.deltas) + 1
+}
+
+// GetUsedBytes ... 使用中のメモリ（バイト）
+func (b *RewindBuffer) GetUsedBytes() int {
+	return b.usedBytes
+}
+
+// Push ... スナップショットを追加
+func (b *RewindBuffer) Push(state []byte) error {
+	if b.latest != nil {
+		d, err := encodeDelta(b.latest, state)
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+		b.deltas = append(b.deltas, d)
+		b.usedBytes = b.usedBytes - len(b.latest) + len(d)
+	}
+
+	b.latest = append([]byte{}, state...)
+	b.usedBytes = b.usedBytes + len(b.latest)
+
+	for b.usedBytes > b.config.MaxBytes && len(b.deltas) > 0 {
+		b.usedBytes = b.usedBytes - len(b.deltas[0])
+		b.deltas[0] = nil
+		b.deltas = b.deltas[1:]
+	}
+	return nil
+}
+
+// Pop ... 最新のスナップショットを取り出す（空の場合はfalse）
+func (b *RewindBuffer) Pop() ([]byte, bool, error) {
+	if b.latest == nil {
+		return nil, false, nil
+	}
+
+	state := b.latest
+	b.usedBytes = b.usedBytes - len(state)
+	b.latest = nil
+
+	if len(b.deltas) > 0 {
+		last := len(b.deltas) - 1
+		d := b.deltas[last]
+		prev, err := decodeDelta(state, d)
+		if err != nil {
+			return nil, false, xerrors.Errorf(": %w", err)
+		}
+		b.deltas = b.deltas[:last]
+		b.usedBytes = b.usedBytes - len(d) + len(prev)
+		b.latest = prev
+	}
+
+	return state, true, nil
+}
+
+// Clear ...
+func (b *RewindBuffer) Clear() {
+	b.latest = nil
+	b.deltas = [][]byte{}
+	b.usedBytes = 0
+}
+
+// encodeDelta ... newerを元にolderを復元するための差分を作成
+//
+//	0x00-0x03	olderの長さ（ビッグエンディアン）
+//	0x04-		olderとnewerのXOR（短い方は0で埋める）をflateで圧縮したもの
+func encodeDelta(older, newer []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	size := make([]byte, 4)
+	binary.BigEndian.PutUint32(size, uint32(len(older)))
+	buf.Write(size)
+
+	w, err := flate.NewWriter(&buf, flate.BestSpeed)
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	if _, err := w.Write(xorBytes(older, newer, len(older))); err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeDelta ... newerと差分からolderを復元
+func decodeDelta(newer, delta []byte) ([]byte, error) {
+	if len(delta) < 4 {
+		return nil, xerrors.Errorf("delta is too short; len: %v", len(delta))
+	}
+	size := int(binary.BigEndian.Uint32(delta[:4]))
+
+	r := flate.NewReader(bytes.NewReader(delta[4:]))
+	defer r.Close()
+	x, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	if len(x) != size {
+		return nil, xerrors.Errorf("wrong delta size; want: %v, got: %v", size, len(x))
+	}
+	return xorBytes(x, newer, size), nil
+}
+
+// xorBytes ... aとbのXOR（長さはsize、足りない部分は0として扱う）
+func xorBytes(a, b []byte, size int) []byte {
+	out := make([]byte, size)
+	for i := 0; i < size; i++ {
+		var x, y byte
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		out[i] = x ^ y
+	}
+	return out
+}
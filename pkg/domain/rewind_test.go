@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRewindBuffer(t *testing.T) {
+	states := [][]byte{
+		[]byte("state-0: 0123456789"),
+		[]byte("state-1: 0123456789abc"),
+		[]byte("state-2: 012345"),
+		[]byte("state-3: 0123456789"),
+	}
+
+	t.Run("when popped, snapshots are returned in reverse order", func(t *testing.T) {
+		b := NewRewindBuffer(RewindConfig{Interval: 1, MaxBytes: 1024})
+		for _, s := range states {
+			if err := b.Push(s); err != nil {
+				t.Fatalf("failed to push: %v", err)
+			}
+		}
+
+		for i := len(states) - 1; i >= 0; i-- {
+			got, ok, err := b.Pop()
+			if err != nil || !ok {
+				t.Fatalf("failed to pop: ok=%v, err=%v", ok, err)
+			}
+			if !bytes.Equal(got, states[i]) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", string(states[i]), string(got))
+			}
+		}
+
+		if _, ok, _ := b.Pop(); ok {
+			t.Errorf("buffer is not empty")
+		}
+		if b.GetUsedBytes() != 0 {
+			t.Errorf("wrong used bytes\nwant: %#v\ngot: %#v", 0, b.GetUsedBytes())
+		}
+	})
+
+	t.Run("when memory exceeds the limit, old snapshots are dropped", func(t *testing.T) {
+		b := NewRewindBuffer(RewindConfig{Interval: 1, MaxBytes: 1})
+		for _, s := range states {
+			if err := b.Push(s); err != nil {
+				t.Fatalf("failed to push: %v", err)
+			}
+		}
+
+		if b.Len() != 1 {
+			t.Errorf("wrong length\nwant: %#v\ngot: %#v", 1, b.Len())
+		}
+		got, _, _ := b.Pop()
+		if !bytes.Equal(got, states[len(states)-1]) {
+			t.Errorf("wrong output\nwant: %#v\ngot: %#v", string(states[len(states)-1]), string(got))
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"crypto/md5"
+	"hash/crc32"
 	"io/ioutil"
 	"nes-go/pkg/log"
 	"os"
@@ -13,7 +15,40 @@ import (
 type INESHeader struct {
 	PRGROMSize uint8 // 4: Size of PRG ROM in 16 KB units
 	CHRROMSize uint8 // 5: Size of CHR ROM in 8 KB units (Value 0 means the board uses CHR RAM)
+	Flags6     uint8 // 6: Mapper, mirroring, battery, trainer
+	Flags7     uint8 // 7: Mapper, VS/Playchoice, NES 2.0
+	Flags9     uint8 // 9: TV system (rarely used extension)
+	Timing     uint8 // 12: CPU/PPU timing (NES 2.0 only)
+}
+
+// HasBattery ... PRG-RAM（0x6000-0x7FFF）がバッテリーバックアップされているか
+func (h *INESHeader) HasBattery() bool {
+	return (h.Flags6 & 0x02) == 0x02
+}
 
+// IsNES20 ... NES 2.0形式のヘッダか
+func (h *INESHeader) IsNES20() bool {
+	return (h.Flags7 & 0x0C) == 0x08
+}
+
+// GetRegion ... ヘッダに記録されている地域（記録されていない場合はfalse）
+// 仕様: https://wiki.nesdev.com/w/index.php/NES_2.0#Byte_12_.28CPU.2FPPU_Timing.29
+func (h *INESHeader) GetRegion() (Region, bool) {
+	if h.IsNES20() {
+		switch h.Timing & 0x03 {
+		case 0x01:
+			return RegionPAL, true
+		case 0x03:
+			return RegionDendy, true
+		default:
+			// 0x02(複数地域対応)はNTSCとして扱う
+			return RegionNTSC, true
+		}
+	}
+	if (h.Flags9 & 0x01) == 0x01 {
+		return RegionPAL, true
+	}
+	return RegionNTSC, false
 }
 
 // PRGROM ...
@@ -38,6 +73,21 @@ type ROM struct {
 	Header *INESHeader
 	Prgrom *PRGROM
 	Chrrom *CHRROM
+	CRC32  uint32   // PRG-ROMとCHR-ROMのCRC32（ゲームデータベースの検索に使用）
+	MD5    [16]byte // PRG-ROMとCHR-ROMのMD5（ムービーのROMチェックサムに使用）
+}
+
+// GetRegion ... NES 2.0ヘッダ、ゲームデータベース、iNESヘッダの順に地域を判定
+func (r *ROM) GetRegion() Region {
+	if r.Header.IsNES20() {
+		region, _ := r.Header.GetRegion()
+		return region
+	}
+	if g, ok := gameDatabase[r.CRC32]; ok {
+		return g.Region
+	}
+	region, _ := r.Header.GetRegion()
+	return region
 }
 
 func readFile(p string) ([]byte, error) {
@@ -62,13 +112,18 @@ func parseINESHeader(rom []byte) (*INESHeader, error) {
 		return nil, xerrors.New("failed to parse, rom is too short")
 	}
 
-	prg := uint8(rom[4])
-	chr := uint8(rom[5])
+	h := INESHeader{
+		PRGROMSize: uint8(rom[4]),
+		CHRROMSize: uint8(rom[5]),
+	}
+	if len(rom) >= 16 {
+		h.Flags6 = uint8(rom[6])
+		h.Flags7 = uint8(rom[7])
+		h.Flags9 = uint8(rom[9])
+		h.Timing = uint8(rom[12])
+	}
 
-	return &INESHeader{
-		PRGROMSize: prg,
-		CHRROMSize: chr,
-	}, nil
+	return &h, nil
 }
 
 func parseROM(rom []byte) (*ROM, error) {
@@ -92,6 +147,8 @@ func parseROM(rom []byte) (*ROM, error) {
 		Header: h,
 		Prgrom: &p,
 		Chrrom: &c,
+		CRC32:  crc32.ChecksumIEEE(rom[begin:chrromEnd]),
+		MD5:    md5.Sum(rom[begin:chrromEnd]),
 	}, nil
 }
 
@@ -22,6 +22,10 @@ func TestParseINESHeader(t *testing.T) {
 			want: &INESHeader{
 				PRGROMSize: 0x02,
 				CHRROMSize: 0x01,
+				Flags6:     0x01,
+				Flags7:     0x00,
+				Flags9:     0x00,
+				Timing:     0x00,
 			},
 			makeWantErr: func() error { return nil },
 		},
@@ -223,3 +227,58 @@ func openROM(p string) ([]byte, error) {
 	}
 	return b, nil
 }
+
+func TestINESHeaderGetRegion(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    INESHeader
+		want      Region
+		wantFound bool
+	}{
+		{
+			name:      "when header is iNES and TV system is not set, return NTSC (not found)",
+			header:    INESHeader{},
+			want:      RegionNTSC,
+			wantFound: false,
+		},
+		{
+			name:      "when header is iNES and TV system is PAL, return PAL",
+			header:    INESHeader{Flags9: 0x01},
+			want:      RegionPAL,
+			wantFound: true,
+		},
+		{
+			name:      "when header is NES 2.0 and timing is NTSC, return NTSC",
+			header:    INESHeader{Flags7: 0x08, Timing: 0x00},
+			want:      RegionNTSC,
+			wantFound: true,
+		},
+		{
+			name:      "when header is NES 2.0 and timing is PAL, return PAL",
+			header:    INESHeader{Flags7: 0x08, Timing: 0x01},
+			want:      RegionPAL,
+			wantFound: true,
+		},
+		{
+			name:      "when header is NES 2.0 and timing is multiple-region, return NTSC",
+			header:    INESHeader{Flags7: 0x08, Timing: 0x02},
+			want:      RegionNTSC,
+			wantFound: true,
+		},
+		{
+			name:      "when header is NES 2.0 and timing is Dendy, return Dendy",
+			header:    INESHeader{Flags7: 0x08, Timing: 0x03},
+			want:      RegionDendy,
+			wantFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := tt.header.GetRegion()
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("wrong output\ngot: %v, %v\nwant: %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"io"
+
+	"golang.org/x/xerrors"
+)
+
+// セーブステートの形式
+// 	0x00-0x07	マジックナンバー "NESGOSS\x1A"
+// 	0x08-0x09	バージョン（ビッグエンディアン）
+// 	0x0A-		gobでエンコードしたsaveStateFile（各コンポーネントの状態をセクションとして保持する）
+
+const (
+	// StateVersion ... セーブステートの形式のバージョン（互換性のない変更をしたら上げる）
+	StateVersion uint16 = 1
+)
+
+var stateMagic = []byte("NESGOSS\x1A")
+
+var (
+	// ErrInvalidState ... セーブステートではない
+	ErrInvalidState = xerrors.New("invalid save state")
+	// ErrStateVersionMismatch ... セーブステートのバージョンが異なる
+	ErrStateVersionMismatch = xerrors.New("save state version mismatch")
+	// ErrStateROMMismatch ... セーブステートを保存したROMと異なる
+	ErrStateROMMismatch = xerrors.New("save state was made with another rom")
+	// ErrStateAfterMovieEnd ... セーブステートが記録・再生中のムービーの最後のフレームより後のもの
+	ErrStateAfterMovieEnd = xerrors.New("save state is after the end of the movie")
+)
+
+// saveStateFile ...
+type saveStateFile struct {
+	ROMCRC32 uint32
+	Region   Region
+	Sections []saveStateSection
+}
+
+// saveStateSection ...
+type saveStateSection struct {
+	Name string
+	Data []byte
+}
+
+// EncodeState ... コンポーネントの状態をエンコード
+func EncodeState(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeState ... コンポーネントの状態をデコード
+func DecodeState(data []byte, v interface{}) error {
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// writeSaveState ...
+func writeSaveState(w io.Writer, f *saveStateFile) error {
+	header := make([]byte, len(stateMagic)+2)
+	copy(header, stateMagic)
+	binary.BigEndian.PutUint16(header[len(stateMagic):], StateVersion)
+
+	if _, err := w.Write(header); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if err := gob.NewEncoder(w).Encode(f); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// readSaveState ...
+func readSaveState(r io.Reader) (*saveStateFile, error) {
+	header := make([]byte, len(stateMagic)+2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, xerrors.Errorf("%v: %w", err, ErrInvalidState)
+	}
+	if !bytes.Equal(header[:len(stateMagic)], stateMagic) {
+		return nil, xerrors.Errorf(": %w", ErrInvalidState)
+	}
+	if v := binary.BigEndian.Uint16(header[len(stateMagic):]); v != StateVersion {
+		return nil, xerrors.Errorf("version: %v, supported: %v: %w", v, StateVersion, ErrStateVersionMismatch)
+	}
+
+	f := &saveStateFile{}
+	if err := gob.NewDecoder(r).Decode(f); err != nil {
+		return nil, xerrors.Errorf("%v: %w", err, ErrInvalidState)
+	}
+	return f, nil
+}
+
+// getSection ...
+func (f *saveStateFile) getSection(name string) ([]byte, error) {
+	for _, s := range f.Sections {
+		if s.Name == name {
+			return s.Data, nil
+		}
+	}
+	return nil, xerrors.Errorf("section %v is not found: %w", name, ErrInvalidState)
+}
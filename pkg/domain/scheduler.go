@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"sort"
+
+	"golang.org/x/xerrors"
+)
+
+// Scheduler ... マスタークロックを基準に各コンポーネントとイベントを実行する
+type Scheduler struct {
+	clock      uint64 // 現在のマスタークロック
+	components []*scheduledComponent
+	events     []*scheduledEvent // 実行時刻順
+}
+
+// scheduledComponent ... 分周比ごとに実行されるコンポーネント
+type scheduledComponent struct {
+	name      string
+	divider   uint64
+	nextClock uint64
+	step      func() (int, error) // 実行し、消費した（そのコンポーネントの）サイクル数を返す
+}
+
+// scheduledEvent ... 指定した時刻に1回だけ実行されるイベント
+type scheduledEvent struct {
+	name  string
+	clock uint64
+	fn    func() error
+}
+
+// NewScheduler ...
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		clock:      0,
+		components: []*scheduledComponent{},
+		events:     []*scheduledEvent{},
+	}
+}
+
+// AddComponent ... コンポーネントを登録し、その番号を返す
+// 同じ時刻に実行すべきコンポーネントが複数ある場合は登録順に実行する
+func (s *Scheduler) AddComponent(name string, divider int, startClock uint64, step func() (int, error)) int {
+	s.components = append(s.components, &scheduledComponent{
+		name:      name,
+		divider:   uint64(divider),
+		nextClock: startClock,
+		step:      step,
+	})
+	return len(s.components) - 1
+}
+
+// ScheduleEvent ... 現在時刻からdelay（マスタークロック）後にfnを実行する
+// 同じ時刻のコンポーネントよりも先に実行する
+func (s *Scheduler) ScheduleEvent(name string, delay uint64, fn func() error) {
+	e := &scheduledEvent{
+		name:  name,
+		clock: s.clock + delay,
+		fn:    fn,
+	}
+	idx := sort.Search(len(s.events), func(i int) bool {
+		return s.events[i].clock > e.clock
+	})
+	s.events = append(s.events, nil)
+	copy(s.events[idx+1:], s.events[idx:])
+	s.events[idx] = e
+}
+
+// GetClock ... 現在のマスタークロック
+func (s *Scheduler) GetClock() uint64 {
+	return s.clock
+}
+
+// Step ... 次に実行すべきイベントもしくはコンポーネントを1つ実行する
+// コンポーネントを実行した場合はその番号、イベントを実行した場合は-1を返す
+func (s *Scheduler) Step() (int, error) {
+	if len(s.components) == 0 {
+		return -1, xerrors.New("no component is registered")
+	}
+
+	next := 0
+	for i, c := range s.components {
+		if c.nextClock < s.components[next].nextClock {
+			next = i
+		}
+	}
+	c := s.components[next]
+
+	if len(s.events) > 0 && s.events[0].clock <= c.nextClock {
+		if err := s.runEvent(); err != nil {
+			return -1, xerrors.Errorf(": %w", err)
+		}
+		return -1, nil
+	}
+
+	if err := s.runComponent(next); err != nil {
+		return next, xerrors.Errorf(": %w", err)
+	}
+	return next, nil
+}
+
+// runEvent ... 先頭のイベントを実行する
+func (s *Scheduler) runEvent() error {
+	e := s.events[0]
+	s.events = s.events[1:]
+	if e.clock > s.clock {
+		s.clock = e.clock
+	}
+	if err := e.fn(); err != nil {
+		return xerrors.Errorf("failed to run event[%v]: %w", e.name, err)
+	}
+	return nil
+}
+
+// runComponent ...
+func (s *Scheduler) runComponent(id int) error {
+	c := s.components[id]
+	s.clock = c.nextClock
+	cycle, err := c.step()
+	if err != nil {
+		return xerrors.Errorf("failed to run component[%v]: %w", c.name, err)
+	}
+	if cycle <= 0 {
+		cycle = 1
+	}
+	c.nextClock = c.nextClock + uint64(cycle)*c.divider
+	return nil
+}
+
+// CatchUp ... 実行中のコンポーネント（id）の途中で、その先頭からcycleサイクル後の時刻まで他のコンポーネントとイベントを進める
+// その時刻と同時のコンポーネントは実行しない（同時刻の場合はidのコンポーネントが先）が、イベントは実行する
+// CPUが命令の途中でPPUのレジスタなどにアクセスする前に使う。現在時刻はその時刻になる
+func (s *Scheduler) CatchUp(id int, cycle int) error {
+	running := s.components[id]
+	clock := running.nextClock + uint64(cycle)*running.divider
+
+	for {
+		next := -1
+		for i, c := range s.components {
+			if i == id {
+				continue
+			}
+			if next < 0 || c.nextClock < s.components[next].nextClock {
+				next = i
+			}
+		}
+
+		if len(s.events) > 0 && s.events[0].clock <= clock && (next < 0 || s.events[0].clock <= s.components[next].nextClock) {
+			if err := s.runEvent(); err != nil {
+				return xerrors.Errorf(": %w", err)
+			}
+			continue
+		}
+		if next < 0 || s.components[next].nextClock >= clock {
+			break
+		}
+		if err := s.runComponent(next); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+
+	if clock > s.clock {
+		s.clock = clock
+	}
+	return nil
+}
+
+// Stall ... コンポーネント（id）の次の実行をcycleサイクル（そのコンポーネントのサイクル）遅らせる
+// 実行中に呼んだ場合は、その実行で消費したサイクルの後に遅れる
+func (s *Scheduler) Stall(id int, cycle int) {
+	c := s.components[id]
+	c.nextClock = c.nextClock + uint64(cycle)*c.divider
+}
+
+// RunUntil ... 指定したコンポーネントが1回実行されるまで進める
+func (s *Scheduler) RunUntil(id int) error {
+	for {
+		stepped, err := s.Step()
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+		if stepped == id {
+			return nil
+		}
+	}
+}
+
+// SchedulerState ... セーブステート用の状態（イベントは関数を含むため保存しない）
+type SchedulerState struct {
+	Clock      uint64
+	NextClocks []uint64
+}
+
+// GetState ...
+func (s *Scheduler) GetState() SchedulerState {
+	st := SchedulerState{
+		Clock:      s.clock,
+		NextClocks: make([]uint64, len(s.components)),
+	}
+	for i, c := range s.components {
+		st.NextClocks[i] = c.nextClock
+	}
+	return st
+}
+
+// SetState ... 予約済のイベントは破棄する
+func (s *Scheduler) SetState(st SchedulerState) error {
+	if len(st.NextClocks) != len(s.components) {
+		return xerrors.Errorf("component count mismatch; want: %v, got: %v", len(s.components), len(st.NextClocks))
+	}
+	s.clock = st.Clock
+	for i, c := range s.components {
+		c.nextClock = st.NextClocks[i]
+	}
+	s.events = []*scheduledEvent{}
+	return nil
+}
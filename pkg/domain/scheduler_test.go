@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSchedulerStep(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []uint64 // イベントのdelay
+		steps  int
+		want   []string
+	}{
+		{
+			name:  "when components run at the same clock, they run in registration order",
+			steps: 5,
+			want:  []string{"CPU@0", "PPU@0", "PPU@4", "PPU@8", "CPU@12"},
+		},
+		{
+			name:   "when an event is scheduled at the same clock as components, the event runs first",
+			events: []uint64{12, 4},
+			steps:  7,
+			want:   []string{"CPU@0", "PPU@0", "event@4", "PPU@4", "PPU@8", "event@12", "CPU@12"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			s := NewScheduler()
+			record := func(name string) {
+				got = append(got, fmt.Sprintf("%s@%d", name, s.GetClock()))
+			}
+			s.AddComponent("CPU", 12, 0, func() (int, error) {
+				record("CPU")
+				return 1, nil
+			})
+			s.AddComponent("PPU", 4, 0, func() (int, error) {
+				record("PPU")
+				return 1, nil
+			})
+			for _, delay := range tt.events {
+				s.ScheduleEvent("event", delay, func() error {
+					record("event")
+					return nil
+				})
+			}
+
+			for i := 0; i < tt.steps; i++ {
+				if _, err := s.Step(); err != nil {
+					t.Fatalf("failed to step: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSchedulerRunUntil(t *testing.T) {
+	s := NewScheduler()
+	ppu := 0
+	cpuID := s.AddComponent("CPU", 12, 0, func() (int, error) {
+		return 2, nil
+	})
+	s.AddComponent("PPU", 4, 0, func() (int, error) {
+		ppu++
+		return 1, nil
+	})
+
+	// 1回目は時刻0のCPUのみ、2回目は時刻24のCPUまでにPPUが6dot進む
+	for i := 0; i < 2; i++ {
+		if err := s.RunUntil(cpuID); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+	}
+	if ppu != 6 {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", 6, ppu)
+	}
+}
+
+func TestSchedulerCatchUp(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []uint64 // イベントのdelay
+		cycles []int    // CPUの1回目の実行中にCatchUpに渡すサイクル数
+		want   []string
+	}{
+		{
+			name:   "when CPU catches up in the middle of an instruction, components before the access run first",
+			cycles: []int{1, 3},
+			want:   []string{"CPU@0", "PPU@0", "PPU@4", "PPU@8", "access@12", "PPU@12", "PPU@16", "PPU@20", "PPU@24", "PPU@28", "PPU@32", "access@36"},
+		},
+		{
+			name:   "when an event is scheduled at the access clock, the event runs before the access",
+			events: []uint64{12, 13},
+			cycles: []int{1},
+			want:   []string{"CPU@0", "PPU@0", "PPU@4", "PPU@8", "event@12", "access@12", "PPU@12", "event@13"},
+		},
+		{
+			name:   "when CPU does not catch up, components run after the instruction",
+			cycles: []int{},
+			want:   []string{"CPU@0", "PPU@0", "PPU@4", "PPU@8"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			s := NewScheduler()
+			record := func(name string) {
+				got = append(got, fmt.Sprintf("%s@%d", name, s.GetClock()))
+			}
+			var cpuID int
+			cpuID = s.AddComponent("CPU", 12, 0, func() (int, error) {
+				record("CPU")
+				for _, c := range tt.cycles {
+					if err := s.CatchUp(cpuID, c); err != nil {
+						return 0, err
+					}
+					record("access")
+				}
+				return 4, nil
+			})
+			s.AddComponent("PPU", 4, 0, func() (int, error) {
+				record("PPU")
+				return 1, nil
+			})
+			for _, delay := range tt.events {
+				s.ScheduleEvent("event", delay, func() error {
+					record("event")
+					return nil
+				})
+			}
+
+			// CPUの1回目の実行後、時刻12より前のコンポーネントとイベントまで進める
+			if err := s.RunUntil(cpuID); err != nil {
+				t.Fatalf("failed to run: %v", err)
+			}
+			for len(s.events) > 0 || s.components[1].nextClock < 12 {
+				if _, err := s.Step(); err != nil {
+					t.Fatalf("failed to step: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSchedulerStall(t *testing.T) {
+	s := NewScheduler()
+	clocks := []uint64{}
+	var cpuID int
+	cpuID = s.AddComponent("CPU", 12, 0, func() (int, error) {
+		clocks = append(clocks, s.GetClock())
+		if len(clocks) == 1 {
+			// 実行中に遅らせると、この実行の2サイクルの後に3サイクル遅れる
+			s.Stall(cpuID, 3)
+		}
+		return 2, nil
+	})
+	s.AddComponent("PPU", 4, 0, func() (int, error) {
+		return 1, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := s.RunUntil(cpuID); err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+	}
+
+	want := []uint64{0, 60, 84}
+	if !reflect.DeepEqual(clocks, want) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, clocks)
+	}
+}
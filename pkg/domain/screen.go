@@ -2,7 +2,8 @@ package domain
 
 import "image/color"
 
-// Screen ...
+// Screen ... PPUが返した1フレーム分の画面
+// Imagesなどの中身はPPUが所有しており、PPUが次のScreenを返すまでの間だけ有効（保持する場合はコピーする）
 type Screen struct {
 	TileImages            [][]TileImage
 	SpriteImages          []SpriteImage
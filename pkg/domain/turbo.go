@@ -0,0 +1,49 @@
+package domain
+
+import "golang.org/x/xerrors"
+
+const (
+	// DefaultTurboRate ... 連射の周期のデフォルト（4フレームで1回押す、60fpsで秒間15回）
+	DefaultTurboRate = 4
+	// MinTurboRate ... 押したフレームと離したフレームが1つずつ必要
+	MinTurboRate = 2
+)
+
+// turboButtons ... 連射ボタンと連射するボタン
+var turboButtons = []struct {
+	turbo  ButtonType
+	button ButtonType
+}{
+	{ButtonTypeTurboA, ButtonTypeA},
+	{ButtonTypeTurboB, ButtonTypeB},
+}
+
+// validateTurboRate ...
+func validateTurboRate(rate int) error {
+	if rate < MinTurboRate {
+		return xerrors.Errorf("turbo rate must be %v or more: %v", MinTurboRate, rate)
+	}
+	return nil
+}
+
+// isTurboPressed ... 連射ボタンを押しているときに、frameでボタンを押したことにするか
+// 実時間ではなくエミュレーションのフレーム数で決めるので、同じ入力なら必ず同じ結果になる
+// 1周期のうち前半（奇数の場合は長い方）を押したことにする
+func isTurboPressed(rate int, frame int) bool {
+	return frame%rate < (rate+1)/2
+}
+
+// readPadState ... padの入力を取得し、連射ボタンをframeに応じてA・Bに反映する
+func readPadState(p Pad, turboRate int, frame int) PadState {
+	s := NewPadState(p)
+	if !isTurboPressed(turboRate, frame) {
+		return s
+	}
+
+	for _, t := range turboButtons {
+		if p.IsPressed(t.turbo) {
+			s = s | padStateBit(t.button)
+		}
+	}
+	return s
+}
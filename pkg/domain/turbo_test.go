@@ -0,0 +1,53 @@
+package domain
+
+import "testing"
+
+func TestReadPadState(t *testing.T) {
+	tests := []struct {
+		name    string
+		pressed map[ButtonType]bool
+		rate    int
+		want    []PadState // フレーム0から順に
+	}{
+		{
+			name:    "when turbo A is pressed with rate 2, A toggles every frame",
+			pressed: map[ButtonType]bool{ButtonTypeTurboA: true},
+			rate:    2,
+			want:    []PadState{0x01, 0x00, 0x01, 0x00},
+		},
+		{
+			name:    "when turbo B is pressed with rate 3, B is pressed for 2 frames and released for 1 frame",
+			pressed: map[ButtonType]bool{ButtonTypeTurboB: true},
+			rate:    3,
+			want:    []PadState{0x02, 0x02, 0x00, 0x02, 0x02, 0x00},
+		},
+		{
+			name:    "when A and turbo A are pressed, A is always pressed",
+			pressed: map[ButtonType]bool{ButtonTypeA: true, ButtonTypeTurboA: true},
+			rate:    2,
+			want:    []PadState{0x01, 0x01, 0x01, 0x01},
+		},
+		{
+			name:    "when turbo is not pressed, other buttons are not changed",
+			pressed: map[ButtonType]bool{ButtonTypeStart: true},
+			rate:    2,
+			want:    []PadState{0x08, 0x08},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pad := &stubPad{pressed: tt.pressed}
+			got := []PadState{}
+			for frame := range tt.want {
+				got = append(got, readPadState(pad, tt.rate, frame))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package domain
+
+import "golang.org/x/xerrors"
+
+const (
+	// VausMinValue ... つまみを左端まで回したときの値
+	VausMinValue = 0x62
+	// VausMaxValue ... つまみを右端まで回したときの値
+	VausMaxValue = 0xF2
+)
+
+// vausPaddle ... Vausのつまみ（ポテンショメータ）の値を送り出す8bitのシフトレジスタと発射ボタン
+// ストローブでつまみの値を読み込み、1回読むごとに最上位ビットから反転して1bitずつ送り出す
+// 仕様: https://wiki.nesdev.com/w/index.php/Arkanoid_controller
+type vausPaddle struct {
+	input  VausInput
+	strobe bool
+	shift  byte
+}
+
+// getValue ... つまみの位置をVausMinValue-VausMaxValueの値にする
+func (v *vausPaddle) getValue() byte {
+	p := v.input.GetPosition()
+	if p < 0 {
+		p = 0
+	}
+	if p > 1 {
+		p = 1
+	}
+	return VausMinValue + byte(p*(VausMaxValue-VausMinValue)+0.5)
+}
+
+// latch ... つまみの値を反転してシフトレジスタに読み込む
+func (v *vausPaddle) latch() {
+	v.shift = ^v.getValue()
+}
+
+// Load ...
+func (v *vausPaddle) Load() error {
+	return v.input.Load()
+}
+
+// Strobe ...
+func (v *vausPaddle) Strobe(out byte) {
+	v.strobe = out&PortStrobe == PortStrobe
+	if v.strobe {
+		v.latch()
+	}
+}
+
+// readData ... つまみの値の次のビット
+func (v *vausPaddle) readData() byte {
+	if v.strobe {
+		v.latch()
+		return v.shift >> 7
+	}
+
+	d := v.shift >> 7
+	v.shift = v.shift << 1
+	return d
+}
+
+// peekData ...
+func (v *vausPaddle) peekData() byte {
+	if v.strobe {
+		return ^v.getValue() >> 7
+	}
+	return v.shift >> 7
+}
+
+// readFire ... 発射ボタンを押している場合は1
+func (v *vausPaddle) readFire() byte {
+	if v.input.IsFirePressed() {
+		return 1
+	}
+	return 0
+}
+
+// vausPaddleState ... セーブステート用の状態
+type vausPaddleState struct {
+	Strobe bool
+	Shift  byte
+}
+
+// MarshalState ...
+func (v *vausPaddle) MarshalState() ([]byte, error) {
+	data, err := EncodeState(vausPaddleState{Strobe: v.strobe, Shift: v.shift})
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (v *vausPaddle) UnmarshalState(data []byte) error {
+	s := vausPaddleState{}
+	if err := DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	v.strobe = s.Strobe
+	v.shift = s.Shift
+	return nil
+}
+
+// Vaus ... NES版のVaus（2Pのポートに接続する）
+// $4017のD3につまみの値、D4に発射ボタンを返す
+type Vaus struct {
+	vausPaddle
+}
+
+// NewVaus ...
+func NewVaus(input VausInput) *Vaus {
+	return &Vaus{
+		vausPaddle: vausPaddle{input: input},
+	}
+}
+
+// Read ...
+func (v *Vaus) Read() byte {
+	return v.readData()<<3 | v.readFire()<<4
+}
+
+// Peek ...
+func (v *Vaus) Peek() byte {
+	return v.peekData()<<3 | v.readFire()<<4
+}
+
+// FamicomVaus ... ファミコン版のVaus（拡張端子に接続する）
+// $4016のD1に発射ボタン、$4017のD1につまみの値を返す
+type FamicomVaus struct {
+	vausPaddle
+}
+
+// NewFamicomVaus ...
+func NewFamicomVaus(input VausInput) *FamicomVaus {
+	return &FamicomVaus{
+		vausPaddle: vausPaddle{input: input},
+	}
+}
+
+// Read ...
+func (v *FamicomVaus) Read(port int) byte {
+	if port == 0 {
+		return v.readFire() << 1
+	}
+	return v.readData() << 1
+}
+
+// Peek ...
+func (v *FamicomVaus) Peek(port int) byte {
+	if port == 0 {
+		return v.readFire() << 1
+	}
+	return v.peekData() << 1
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// stubVausInput ...
+type stubVausInput struct {
+	position float64
+	fire     bool
+}
+
+func (i *stubVausInput) Load() error {
+	return nil
+}
+
+func (i *stubVausInput) GetPosition() float64 {
+	return i.position
+}
+
+func (i *stubVausInput) IsFirePressed() bool {
+	return i.fire
+}
+
+func TestVaus(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *stubVausInput
+		want  []byte
+	}{
+		{
+			name:  "when the knob is at the left end, send inverted 0x62 from MSB on D3",
+			input: &stubVausInput{position: 0},
+			want:  []byte{0x08, 0x00, 0x00, 0x08, 0x08, 0x08, 0x00, 0x08},
+		},
+		{
+			name:  "when the knob is at the right end, send inverted 0xF2",
+			input: &stubVausInput{position: 1},
+			want:  []byte{0x00, 0x00, 0x00, 0x00, 0x08, 0x08, 0x00, 0x08},
+		},
+		{
+			name:  "when the position is out of range, clamp it",
+			input: &stubVausInput{position: 2},
+			want:  []byte{0x00, 0x00, 0x00, 0x00, 0x08, 0x08, 0x00, 0x08},
+		},
+		{
+			name:  "when fire is pressed, set D4 on every read",
+			input: &stubVausInput{position: 0, fire: true},
+			want:  []byte{0x18, 0x10, 0x10, 0x18, 0x18, 0x18, 0x10, 0x18},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readPort(NewVaus(tt.input), len(tt.want))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFamicomVaus(t *testing.T) {
+	v := NewFamicomVaus(&stubVausInput{position: 1, fire: true})
+	port1 := NewFamicomExpansionPort(&stubPad{}, v, 0)
+	port2 := NewFamicomExpansionPort(&stubPad{}, v, 1)
+
+	port1.Strobe(1)
+	port2.Strobe(1)
+	port1.Strobe(0)
+	port2.Strobe(0)
+	got := [2][]byte{}
+	for i := 0; i < 8; i++ {
+		got[0] = append(got[0], port1.Read())
+		got[1] = append(got[1], port2.Read())
+	}
+
+	// 標準コントローラーは何も押していないのでD0は0
+	want := [2][]byte{
+		{0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02, 0x02},
+		{0x00, 0x00, 0x00, 0x00, 0x02, 0x02, 0x00, 0x02},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+	}
+}
@@ -39,3 +39,25 @@ func NewVRAM() *VRAM {
 		SpritePalette:     sp,
 	}
 }
+
+// copyFrom ... 参照を保ったままsrcの内容をコピー
+func (v *VRAM) copyFrom(src *VRAM) {
+	copy(v.NameTable0, src.NameTable0)
+	copy(v.AttributeTable0, src.AttributeTable0)
+	copy(v.NameTable1, src.NameTable1)
+	copy(v.AttributeTable1, src.AttributeTable1)
+	copy(v.NameTable2, src.NameTable2)
+	copy(v.AttributeTable2, src.AttributeTable2)
+	copy(v.NameTable3, src.NameTable3)
+	copy(v.AttributeTable3, src.AttributeTable3)
+	for i := range v.BackgroundPalette {
+		if i < len(src.BackgroundPalette) {
+			copy(v.BackgroundPalette[i], src.BackgroundPalette[i])
+		}
+	}
+	for i := range v.SpritePalette {
+		if i < len(src.SpritePalette) {
+			copy(v.SpritePalette[i], src.SpritePalette[i])
+		}
+	}
+}
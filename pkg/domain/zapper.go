@@ -0,0 +1,91 @@
+package domain
+
+import "image/color"
+
+const (
+	// zapperLightSense ... 光を検出していない場合に1（D3）
+	zapperLightSense = 0x08
+	// zapperTrigger ... 引き金を引いている場合に1（D4）
+	zapperTrigger = 0x10
+
+	// zapperLightDots ... 画素が出力されてから光を検出し続ける期間（約20スキャンライン）
+	zapperLightDots = 20 * 341
+	// zapperRadius ... 照準の周りで光を検出する範囲（ピクセル）
+	zapperRadius = 2
+	// zapperBrightness ... 光を検出する明るさ（輝度、0-255）
+	zapperBrightness = 0x80
+)
+
+// Zapper ... 光線銃（2Pのポートに接続する）
+// 照準付近の画素のうち、直近に出力された明るい画素があれば光を検出する
+// 仕様: https://wiki.nesdev.com/w/index.php/Zapper
+type Zapper struct {
+	ppu   PPU
+	input ZapperInput
+}
+
+// NewZapper ...
+func NewZapper(ppu PPU, input ZapperInput) *Zapper {
+	return &Zapper{
+		ppu:   ppu,
+		input: input,
+	}
+}
+
+// Load ...
+func (z *Zapper) Load() error {
+	return z.input.Load()
+}
+
+// Strobe ... Zapperはストローブを使わない
+func (z *Zapper) Strobe(out byte) {}
+
+// Read ...
+func (z *Zapper) Read() byte {
+	var d byte
+	if !z.senseLight() {
+		d = d | zapperLightSense
+	}
+	if z.input.IsTriggerPulled() {
+		d = d | zapperTrigger
+	}
+	return d
+}
+
+// Peek ...
+func (z *Zapper) Peek() byte {
+	return z.Read()
+}
+
+// senseLight ...
+func (z *Zapper) senseLight() bool {
+	x, y, ok := z.input.GetAim()
+	if !ok {
+		return false
+	}
+
+	for dy := -zapperRadius; dy <= zapperRadius; dy++ {
+		for dx := -zapperRadius; dx <= zapperRadius; dx++ {
+			c, dotsAgo, ok := z.ppu.GetOutputPixel(x+dx, y+dy)
+			if ok && dotsAgo <= zapperLightDots && getBrightness(c) >= zapperBrightness {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getBrightness ... 輝度（ITU-R BT.601）
+func getBrightness(c color.RGBA) int {
+	return (int(c.R)*299 + int(c.G)*587 + int(c.B)*114) / 1000
+}
+
+// MarshalState ... 保存する状態はない
+func (z *Zapper) MarshalState() ([]byte, error) {
+	return nil, nil
+}
+
+// UnmarshalState ...
+func (z *Zapper) UnmarshalState(data []byte) error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"image/color"
+	"testing"
+)
+
+// stubOutputPPU ... (x, y)の画素だけdotsAgo前に出力済みのPPU
+type stubOutputPPU struct {
+	PPU
+	x, y    int
+	c       color.RGBA
+	dotsAgo int
+}
+
+func (p *stubOutputPPU) GetOutputPixel(x, y int) (color.RGBA, int, bool) {
+	if x != p.x || y != p.y {
+		return color.RGBA{}, 0, false
+	}
+	return p.c, p.dotsAgo, true
+}
+
+// stubZapperInput ...
+type stubZapperInput struct {
+	x, y     int
+	onScreen bool
+	trigger  bool
+}
+
+func (i *stubZapperInput) Load() error {
+	return nil
+}
+
+func (i *stubZapperInput) GetAim() (int, int, bool) {
+	return i.x, i.y, i.onScreen
+}
+
+func (i *stubZapperInput) IsTriggerPulled() bool {
+	return i.trigger
+}
+
+func TestZapper_Read(t *testing.T) {
+	white := color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+	black := color.RGBA{A: 0xFF}
+
+	tests := []struct {
+		name  string
+		ppu   *stubOutputPPU
+		input *stubZapperInput
+		want  byte
+	}{
+		{
+			name:  "when aiming at a bright pixel just output, sense light",
+			ppu:   &stubOutputPPU{x: 100, y: 50, c: white, dotsAgo: 10},
+			input: &stubZapperInput{x: 100, y: 50, onScreen: true},
+			want:  0x00,
+		},
+		{
+			name:  "when a bright pixel is near the aim, sense light",
+			ppu:   &stubOutputPPU{x: 102, y: 48, c: white, dotsAgo: 10},
+			input: &stubZapperInput{x: 100, y: 50, onScreen: true},
+			want:  0x00,
+		},
+		{
+			name:  "when the bright pixel is too far from the aim, not sense light",
+			ppu:   &stubOutputPPU{x: 103, y: 50, c: white, dotsAgo: 10},
+			input: &stubZapperInput{x: 100, y: 50, onScreen: true},
+			want:  0x08,
+		},
+		{
+			name:  "when the pixel is dark, not sense light",
+			ppu:   &stubOutputPPU{x: 100, y: 50, c: black, dotsAgo: 10},
+			input: &stubZapperInput{x: 100, y: 50, onScreen: true},
+			want:  0x08,
+		},
+		{
+			name:  "when the pixel was output long ago, not sense light",
+			ppu:   &stubOutputPPU{x: 100, y: 50, c: white, dotsAgo: 30 * 341},
+			input: &stubZapperInput{x: 100, y: 50, onScreen: true},
+			want:  0x08,
+		},
+		{
+			name:  "when aiming off screen, not sense light",
+			ppu:   &stubOutputPPU{x: 100, y: 50, c: white, dotsAgo: 10},
+			input: &stubZapperInput{x: 100, y: 50, onScreen: false},
+			want:  0x08,
+		},
+		{
+			name:  "when the trigger is pulled, set D4",
+			ppu:   &stubOutputPPU{x: 100, y: 50, c: white, dotsAgo: 10},
+			input: &stubZapperInput{x: 100, y: 50, onScreen: true, trigger: true},
+			want:  0x10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewZapper(tt.ppu, tt.input).Read()
+			if got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
@@ -16,55 +16,118 @@ type Bus struct {
 	exram      []byte
 	programROM *domain.PRGROM
 
-	ppu  domain.PPU
-	cpu  domain.CPU
-	pad1 domain.Pad
-	pad2 domain.Pad
+	ppu   domain.PPU
+	cpu   domain.CPU
+	ports [2]domain.PortDevice // $4016/$4017に接続する機器
+
+	oamDMA func(page byte) error // $4014への書き込みでOAM DMAを開始する
 
 	charactorROM *domain.CHRROM
 
 	vram *domain.VRAM
 
-	pad1ReadCount int
-	pad2ReadCount int
-
-	pad1WriteBuf byte
-	pad2WriteBuf byte
+	openBus byte // CPUのデータバスに最後に乗った値（何も出力しないビットはこの値になる）
 
 	setupped bool
 }
 
 // NewBus ...
 func NewBus() domain.Bus {
-	io := make([]byte, 0x0020)
-	io[0x04] = 0xFF
-	io[0x05] = 0xFF
-	io[0x06] = 0xFF
-	io[0x07] = 0xFF
-	io[0x15] = 0xFF
-
 	return &Bus{
 		wram:  make([]byte, 0x0800),
-		io:    io,
+		io:    make([]byte, domain.IOSize),
 		exrom: make([]byte, 0x1FE0),
 		exram: make([]byte, 0x2000),
 
-		pad1ReadCount: 0,
-		pad2ReadCount: 0,
-
 		setupped: false,
 	}
 }
 
+// PowerOn ... WRAMとI/Oポートを電源投入時の状態にする（拡張RAMはバッテリーバックアップされるため保持する）
+func (b *Bus) PowerOn(p domain.PowerOnProfile) {
+	p.FillRAM(b.wram)
+	copy(b.io, p.IO[:])
+	b.openBus = 0
+
+	for _, d := range b.ports {
+		if d != nil {
+			d.Strobe(0)
+		}
+	}
+}
+
+// busState ... セーブステート用の状態
+type busState struct {
+	WRAM  []byte
+	IO    []byte
+	EXROM []byte
+	EXRAM []byte
+
+	OpenBus byte
+	Ports   [2][]byte
+}
+
+// MarshalState ...
+func (b *Bus) MarshalState() ([]byte, error) {
+	s := busState{
+		WRAM:    b.wram,
+		IO:      b.io,
+		EXROM:   b.exrom,
+		EXRAM:   b.exram,
+		OpenBus: b.openBus,
+	}
+	for i, d := range b.ports {
+		data, err := d.MarshalState()
+		if err != nil {
+			return nil, xerrors.Errorf("port%v: %w", i+1, err)
+		}
+		s.Ports[i] = data
+	}
+
+	data, err := domain.EncodeState(s)
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (b *Bus) UnmarshalState(data []byte) error {
+	s := busState{}
+	if err := domain.DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	copy(b.wram, s.WRAM)
+	copy(b.io, s.IO)
+	copy(b.exrom, s.EXROM)
+	copy(b.exram, s.EXRAM)
+	b.openBus = s.OpenBus
+	for i, d := range b.ports {
+		// ポートの状態がない場合（古いセーブステート）は初期状態のままにする
+		if len(s.Ports[i]) == 0 {
+			continue
+		}
+		if err := d.UnmarshalState(s.Ports[i]); err != nil {
+			return xerrors.Errorf("port%v: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// GetPRGRAM ... 拡張RAM（0x6000-0x7FFF）、バッテリーバックアップの読み書きに使う
+func (b *Bus) GetPRGRAM() []byte {
+	return b.exram
+}
+
 // Setup ...
-func (b *Bus) Setup(rom *domain.ROM, ppu domain.PPU, cpu domain.CPU, vram *domain.VRAM, pad1 domain.Pad, pad2 domain.Pad) {
+func (b *Bus) Setup(rom *domain.ROM, ppu domain.PPU, cpu domain.CPU, vram *domain.VRAM, port1 domain.PortDevice, port2 domain.PortDevice) {
 	b.programROM = rom.Prgrom
 	b.charactorROM = rom.Chrrom
 	b.ppu = ppu
 	b.cpu = cpu
 	b.vram = vram
-	b.pad1 = pad1
-	b.pad2 = pad2
+	b.ports = [2]domain.PortDevice{port1, port2}
 
 	b.setupped = true
 }
@@ -80,6 +143,7 @@ func (b *Bus) ReadByCPU(addr domain.Address) (byte, error) {
 			log.Warn("end[addr=%#v][%v] => %#v", addr, target, err)
 		} else {
 			log.Trace("end[addr=%#v][%v] => %#v", addr, target, data)
+			b.openBus = data
 		}
 	}()
 
@@ -138,70 +202,10 @@ func (b *Bus) ReadByCPU(addr domain.Address) (byte, error) {
 		return data, err
 	}
 
-	// 0x4016 PAD1
-	if addr == 0x4016 {
-		var pressed bool
-		switch b.pad1ReadCount {
-		case 0:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeA)
-		case 1:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeB)
-		case 2:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeSelect)
-		case 3:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeStart)
-		case 4:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeUp)
-		case 5:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeDown)
-		case 6:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeLeft)
-		case 7:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeRight)
-		}
-
-		if pressed {
-			data = 1
-		} else {
-			data = 0
-		}
-
-		if b.pad1ReadCount < 8 {
-			b.pad1ReadCount = b.pad1ReadCount + 1
-		}
-		return data, nil
-	}
-	// 0x4017 PAD2
-	if addr == 0x4017 {
-		var pressed bool
-		switch b.pad2ReadCount {
-		case 0:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeA)
-		case 1:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeB)
-		case 2:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeSelect)
-		case 3:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeStart)
-		case 4:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeUp)
-		case 5:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeDown)
-		case 6:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeLeft)
-		case 7:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeRight)
-		}
-
-		if pressed {
-			data = 1
-		} else {
-			data = 0
-		}
-
-		if b.pad2ReadCount < 8 {
-			b.pad2ReadCount = b.pad2ReadCount + 1
-		}
+	// 0x4016～0x4017	コントローラーポート
+	if addr == 0x4016 || addr == 0x4017 {
+		target = "Controller Port"
+		data = (b.openBus &^ domain.PortDataMask) | (b.ports[addr-0x4016].Read() & domain.PortDataMask)
 		return data, nil
 	}
 
@@ -258,6 +262,7 @@ func (b *Bus) WriteByCPU(addr domain.Address, data byte) error {
 			log.Warn("end[addr=%#v][%v] => %#v", addr, target, err)
 		} else {
 			log.Trace("end[addr=%#v][%v] <= %#v", addr, target, data)
+			b.openBus = data
 		}
 	}()
 
@@ -305,31 +310,28 @@ func (b *Bus) WriteByCPU(addr domain.Address, data byte) error {
 		return err
 	}
 
-	// 0x4014 OAMDMA
+	// 0x4014 OAMDMA（転送とCPUの停止はSetOAMDMAで設定した関数が行う）
 	if addr == 0x4014 {
 		target = "OAMDMA"
-		err = b.ppu.WriteRegisters(addr, data)
+		if b.oamDMA == nil {
+			err = xerrors.New("OAM DMA is not set up")
+			return err
+		}
+		err = b.oamDMA(data)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 		}
 		return err
 	}
 
-	// 0x4016 PAD1
+	// 0x4016 コントローラーポート（OUT0-OUT2は両方のポートにつながっている）
+	// 0x4017への書き込みはAPUのフレームカウンタ
 	if addr == 0x4016 {
-		if b.pad1WriteBuf == 0x01 && data == 0x00 {
-			b.pad1ReadCount = 0
-		}
-		b.pad1WriteBuf = data
-		return nil
-	}
-
-	// 0x4017 PAD2
-	if addr == 0x4017 {
-		if b.pad2WriteBuf == 0x01 && data == 0x00 {
-			b.pad2ReadCount = 0
+		target = "Controller Port"
+		b.io[addr-0x4000] = data
+		for _, d := range b.ports {
+			d.Strobe(data & 0x07)
 		}
-		b.pad2WriteBuf = data
 		return nil
 	}
 
@@ -361,6 +363,23 @@ func (b *Bus) WriteByCPU(addr domain.Address, data byte) error {
 	return xerrors.Errorf("addr out of range; addr: %#v", addr)
 }
 
+// toPPUPhysicalAddress ... PPUのメモリマップにおけるミラー領域を実体のアドレスに変換
+func toPPUPhysicalAddress(addr domain.Address) domain.Address {
+	// 0x3000～0x3EFF	-	0x2000-0x2EFFのミラー
+	if addr >= 0x3000 && addr <= 0x3EFF {
+		return addr - 0x1000
+	}
+	// 0x3F20～0x3FFF	-	0x3F00-0x3F1Fのミラー
+	if addr >= 0x3F20 && addr <= 0x3FFF {
+		addr = 0x3F00 + (addr-0x3F00)%0x20
+	}
+	// 0x3F10,0x3F14,0x3F18,0x3F1C	-	0x3F00,0x3F04,0x3F08,0x3F0Cのミラー
+	if addr >= 0x3F10 && addr <= 0x3F1F && (addr&0x03) == 0 {
+		return addr - 0x10
+	}
+	return addr
+}
+
 // ReadByPPU ...
 func (b *Bus) ReadByPPU(addr domain.Address) (data byte, err error) {
 	var target string
@@ -382,17 +401,7 @@ func (b *Bus) ReadByPPU(addr domain.Address) (data byte, err error) {
 		return data, err
 	}
 
-	addrTmp := addr
-	// 0x3000～0x3EFF	-	0x2000-0x2EFFのミラー
-	if addr >= 0x3000 && addr <= 0x3EFF {
-		var l uint16 = 0x2EFF - 0x2000
-		addrTmp = domain.Address(0x2000 + ((uint16(addr) - 0x3000) % l))
-	}
-	// 0x3F20～0x3FFF	-	0x3F00-0x3F1Fのミラー
-	if addr >= 0x3F20 && addr <= 0x3FFF {
-		var l uint16 = 0x3F1F - 0x3F00
-		addrTmp = domain.Address(0x3F00 + ((uint16(addr) - 0x3F20) % l))
-	}
+	addrTmp := toPPUPhysicalAddress(addr)
 
 	// 0x0000～0x0FFF	0x1000	パターンテーブル0
 	if addrTmp >= 0x0000 && addrTmp <= 0x0FFF {
@@ -505,17 +514,7 @@ func (b *Bus) WriteByPPU(addr domain.Address, data byte) (err error) {
 		err = xerrors.Errorf("bus setup is not completed")
 		return err
 	}
-	addrTmp := addr
-	// 0x3000～0x3EFF	-	0x2000-0x2EFFのミラー
-	if addr >= 0x3000 && addr <= 0x3EFF {
-		var l uint16 = 0x2EFF - 0x2000
-		addrTmp = domain.Address(0x2000 + ((uint16(addr) - 0x3000) % l))
-	}
-	// 0x3F20～0x3FFF	-	0x3F00-0x3F1Fのミラー
-	if addr >= 0x3F20 && addr <= 0x3FFF {
-		var l uint16 = 0x3F1F - 0x3F00
-		addrTmp = domain.Address(0x3F00 + ((uint16(addr) - 0x3F20) % l))
-	}
+	addrTmp := toPPUPhysicalAddress(addr)
 
 	// 0x0000～0x0FFF	0x1000	パターンテーブル0
 	if addrTmp >= 0x0000 && addrTmp <= 0x0FFF {
@@ -592,11 +591,6 @@ func (b *Bus) WriteByPPU(addr domain.Address, data byte) (err error) {
 		pIdx := (addrTmp - 0x3F00) / 4
 		bitIdx := (addrTmp - 0x3F00) % 4
 		b.vram.BackgroundPalette[pIdx][bitIdx] = data
-
-		if bitIdx == 0 {
-			b.vram.SpritePalette[pIdx][bitIdx] = data
-		}
-
 		target = "BackgroundPalette"
 		return
 	}
@@ -605,11 +599,6 @@ func (b *Bus) WriteByPPU(addr domain.Address, data byte) (err error) {
 		pIdx := (addrTmp - 0x3F10) / 4
 		bitIdx := (addrTmp - 0x3F10) % 4
 		b.vram.SpritePalette[pIdx][bitIdx] = data
-
-		if bitIdx == 0 {
-			b.vram.BackgroundPalette[pIdx][bitIdx] = data
-		}
-
 		target = "SpritePalette"
 		return
 	}
@@ -715,6 +704,11 @@ func (b *Bus) SendNMI(active bool) {
 	b.cpu.ReceiveNMI(active)
 }
 
+// SetOAMDMA ...
+func (b *Bus) SetOAMDMA(f func(page byte) error) {
+	b.oamDMA = f
+}
+
 // ReadByRecorder ...
 func (b *Bus) ReadByRecorder(addr domain.Address) (byte, error) {
 	var data byte
@@ -786,64 +780,10 @@ func (b *Bus) ReadByRecorder(addr domain.Address) (byte, error) {
 		return data, err
 	}
 
-	// 0x4016 PAD1
-	if addr == 0x4016 {
-		var pressed bool
-		switch b.pad1ReadCount {
-		case 0:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeA)
-		case 1:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeB)
-		case 2:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeSelect)
-		case 3:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeStart)
-		case 4:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeUp)
-		case 5:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeDown)
-		case 6:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeLeft)
-		case 7:
-			pressed = b.pad1.IsPressed(domain.ButtonTypeRight)
-		}
-
-		if pressed {
-			data = 1
-		} else {
-			data = 0
-		}
-
-		return data, nil
-	}
-	// 0x4017 PAD2
-	if addr == 0x4017 {
-		var pressed bool
-		switch b.pad2ReadCount {
-		case 0:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeA)
-		case 1:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeB)
-		case 2:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeSelect)
-		case 3:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeStart)
-		case 4:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeUp)
-		case 5:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeDown)
-		case 6:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeLeft)
-		case 7:
-			pressed = b.pad2.IsPressed(domain.ButtonTypeRight)
-		}
-
-		if pressed {
-			data = 1
-		} else {
-			data = 0
-		}
-
+	// 0x4016～0x4017	コントローラーポート
+	if addr == 0x4016 || addr == 0x4017 {
+		target = "Controller Port"
+		data = (b.openBus &^ domain.PortDataMask) | (b.ports[addr-0x4016].Peek() & domain.PortDataMask)
 		return data, nil
 	}
 
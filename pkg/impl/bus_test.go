@@ -0,0 +1,150 @@
+package impl_test
+
+import (
+	"testing"
+
+	"nes-go/pkg/domain"
+)
+
+func TestBusPaletteMirror(t *testing.T) {
+	tests := []struct {
+		name      string
+		writeAddr domain.Address
+		readAddr  domain.Address
+		want      byte
+	}{
+		{
+			name:      "When 0x3F10 is written, 0x3F00 is updated",
+			writeAddr: 0x3F10,
+			readAddr:  0x3F00,
+			want:      0x2A,
+		},
+		{
+			name:      "When 0x3F00 is written, 0x3F10 is updated",
+			writeAddr: 0x3F00,
+			readAddr:  0x3F10,
+			want:      0x2A,
+		},
+		{
+			name:      "When 0x3F14 is written, 0x3F04 is updated",
+			writeAddr: 0x3F14,
+			readAddr:  0x3F04,
+			want:      0x2A,
+		},
+		{
+			name:      "When 0x3F08 is written, 0x3F18 is updated",
+			writeAddr: 0x3F08,
+			readAddr:  0x3F18,
+			want:      0x2A,
+		},
+		{
+			name:      "When 0x3F1C is written, 0x3F0C is updated",
+			writeAddr: 0x3F1C,
+			readAddr:  0x3F0C,
+			want:      0x2A,
+		},
+		{
+			name:      "When 0x3F11 is written, 0x3F01 is not updated",
+			writeAddr: 0x3F11,
+			readAddr:  0x3F01,
+			want:      0x00,
+		},
+		{
+			name:      "When 0x3F01 is written, 0x3F11 is not updated",
+			writeAddr: 0x3F01,
+			readAddr:  0x3F11,
+			want:      0x00,
+		},
+		{
+			name:      "When 0x3F30 is written, 0x3F00 is updated through both mirrors",
+			writeAddr: 0x3F30,
+			readAddr:  0x3F00,
+			want:      0x2A,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, bus := setupPPU2(t, nil)
+
+			if err := bus.WriteByPPU(tt.writeAddr, 0x2A); err != nil {
+				t.Fatalf("failed to write: %v", err)
+			}
+			got, err := bus.ReadByPPU(tt.readAddr)
+			if err != nil {
+				t.Fatalf("failed to read: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBusPowerOnIO(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile func() domain.PowerOnProfile
+		addr    domain.Address
+		want    byte
+	}{
+		{
+			name:    "when default profile is used, pulse 2 is read as 0xFF",
+			profile: domain.DefaultPowerOnProfile,
+			addr:    0x4004,
+			want:    0xFF,
+		},
+		{
+			name:    "when default profile is used, APU status is read as 0xFF",
+			profile: domain.DefaultPowerOnProfile,
+			addr:    0x4015,
+			want:    0xFF,
+		},
+		{
+			name:    "when default profile is used, pulse 1 is read as 0x00",
+			profile: domain.DefaultPowerOnProfile,
+			addr:    0x4000,
+			want:    0x00,
+		},
+		{
+			name: "when I/O is cleared in the profile, pulse 2 is read as 0x00",
+			profile: func() domain.PowerOnProfile {
+				p := domain.DefaultPowerOnProfile()
+				p.IO = [domain.IOSize]byte{}
+				return p
+			},
+			addr: 0x4004,
+			want: 0x00,
+		},
+		{
+			name: "when I/O is set in the profile, the value is read",
+			profile: func() domain.PowerOnProfile {
+				p := domain.DefaultPowerOnProfile()
+				p.IO[0x08] = 0x5A
+				return p
+			},
+			addr: 0x4008,
+			want: 0x5A,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, bus := setupPPU2(t, nil)
+
+			// 電源を入れ直すと、書き込んだ値はプロファイルの値に戻る
+			if err := bus.WriteByCPU(tt.addr, 0x33); err != nil {
+				t.Fatalf("failed to write: %v", err)
+			}
+			bus.PowerOn(tt.profile())
+
+			got, err := bus.ReadByCPU(tt.addr)
+			if err != nil {
+				t.Fatalf("failed to read: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
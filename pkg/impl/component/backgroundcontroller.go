@@ -1,7 +1,6 @@
 package component
 
 import (
-	"image/color"
 	"nes-go/pkg/domain"
 )
 
@@ -63,8 +62,8 @@ func (b *BackgroundController) SetupNextData(attrIdx byte) {
 	}
 }
 
-// MakePixel ...
-func (b *BackgroundController) MakePixel(fineX uint8) color.RGBA {
+// MakePixel ... ピクセルの色番号と不透明かどうかを返す
+func (b *BackgroundController) MakePixel(fineX uint8) (uint8, bool) {
 	shift := fineX
 
 	attrL := (b.attributeRegisterL.GetLow() & (0x01 << shift)) >> shift
@@ -77,8 +76,7 @@ func (b *BackgroundController) MakePixel(fineX uint8) color.RGBA {
 	patternH := (b.patternRegisterH.GetLow() & (0x01 << shift)) >> shift
 	pattern := (patternH << 1) | patternL
 
-	red, green, blue := palette.GetColor(pattern)
-	return color.RGBA{R: red, G: green, B: blue, A: 0xFF}
+	return palette.GetColorIndex(pattern), pattern != 0
 }
 
 func swapbit(b byte) byte {
@@ -94,3 +92,42 @@ func swapbit(b byte) byte {
 
 	return swapped
 }
+
+// BackgroundControllerState ... セーブステート用の状態
+type BackgroundControllerState struct {
+	PatternRegisterL   [2]byte
+	PatternRegisterH   [2]byte
+	AttributeRegisterL [2]byte
+	AttributeRegisterH [2]byte
+
+	NextTileIndex      byte
+	NextAttributeTable byte
+	NextTilePatternL   byte
+	NextTilePatternH   byte
+}
+
+// GetState ...
+func (b *BackgroundController) GetState() BackgroundControllerState {
+	return BackgroundControllerState{
+		PatternRegisterL:   b.patternRegisterL.GetState(),
+		PatternRegisterH:   b.patternRegisterH.GetState(),
+		AttributeRegisterL: b.attributeRegisterL.GetState(),
+		AttributeRegisterH: b.attributeRegisterH.GetState(),
+		NextTileIndex:      b.NextTileIndex,
+		NextAttributeTable: b.NextAttributeTable,
+		NextTilePatternL:   b.NextTilePatternL,
+		NextTilePatternH:   b.NextTilePatternH,
+	}
+}
+
+// SetState ...
+func (b *BackgroundController) SetState(s BackgroundControllerState) {
+	b.patternRegisterL.SetState(s.PatternRegisterL)
+	b.patternRegisterH.SetState(s.PatternRegisterH)
+	b.attributeRegisterL.SetState(s.AttributeRegisterL)
+	b.attributeRegisterH.SetState(s.AttributeRegisterH)
+	b.NextTileIndex = s.NextTileIndex
+	b.NextAttributeTable = s.NextAttributeTable
+	b.NextTilePatternL = s.NextTilePatternL
+	b.NextTilePatternH = s.NextTilePatternH
+}
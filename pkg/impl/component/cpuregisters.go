@@ -18,13 +18,13 @@ type CPURegisters struct {
 
 // NewCPURegisters ...
 func NewCPURegisters() *CPURegisters {
-	// initialize as CPU power up state
+	// initialize as CPU power up state (before the reset sequence decrements S by 3)
 	// https://wiki.nesdev.com/w/index.php/CPU_power_up_state
 	return &CPURegisters{
 		A:  0,
 		X:  0,
 		Y:  0,
-		S:  0xFD,
+		S:  0x00,
 		P:  NewCPUStatusRegister(),
 		PC: 0,
 	}
@@ -210,3 +210,35 @@ func (s *CPUStatusRegister) UpdateC(ans uint16) {
 	s.Carry = (ans & 0xFF00) != 0x00
 	log.Trace("CPU.update[C](ans:%#4X) %#v => %#v", ans, old, s.Carry)
 }
+
+// CPURegistersState ... セーブステート用の状態
+type CPURegistersState struct {
+	A  byte
+	X  byte
+	Y  byte
+	S  byte
+	P  byte
+	PC uint16
+}
+
+// GetState ...
+func (r *CPURegisters) GetState() CPURegistersState {
+	return CPURegistersState{
+		A:  r.A,
+		X:  r.X,
+		Y:  r.Y,
+		S:  r.S,
+		P:  r.P.ToByte(),
+		PC: r.PC,
+	}
+}
+
+// SetState ...
+func (r *CPURegisters) SetState(s CPURegistersState) {
+	r.A = s.A
+	r.X = s.X
+	r.Y = s.Y
+	r.S = s.S
+	r.P.UpdateAll(s.P)
+	r.PC = s.PC
+}
@@ -156,7 +156,7 @@ func (a *PPUAddr) ToByte() byte {
 // PPUCtrl ...
 type PPUCtrl struct {
 	NMIEnable                   bool
-	SpriteTileSelect            bool
+	SpriteTileSelect            bool // bit5 0:8x8, 1:8x16
 	BackgroundPatternTableIndex uint8
 	SpritePatternTableIndex     uint8
 	VRAMAddressIncrementMode    uint8
@@ -166,7 +166,7 @@ type PPUCtrl struct {
 // UpdateAll ...
 func (p *PPUCtrl) UpdateAll(b byte) {
 	p.NMIEnable = (b & 0x80) == 0x80
-	p.SpriteTileSelect = (b & 0x04) == 0x04
+	p.SpriteTileSelect = (b & 0x20) == 0x20
 	p.BackgroundPatternTableIndex = (b & 0x10) >> 4
 	p.SpritePatternTableIndex = (b & 0x08) >> 3
 	p.VRAMAddressIncrementMode = (b & 0x04) >> 2
@@ -179,7 +179,7 @@ func (p *PPUCtrl) ToByte() byte {
 		b = b | 0x80
 	}
 	if p.SpriteTileSelect {
-		b = b | 0x04
+		b = b | 0x20
 	}
 	b = b | (p.BackgroundPatternTableIndex << 4)
 	b = b | (p.SpritePatternTableIndex << 3)
@@ -187,6 +187,14 @@ func (p *PPUCtrl) ToByte() byte {
 	return b
 }
 
+// GetSpriteHeight ... スプライトの高さ（8x8モードなら8、8x16モードなら16）
+func (p *PPUCtrl) GetSpriteHeight() uint16 {
+	if p.SpriteTileSelect {
+		return domain.LargeSpriteHeight
+	}
+	return domain.SpriteHeight
+}
+
 // PPUMask ...
 type PPUMask struct {
 	EmphasizeB            bool
@@ -239,6 +247,26 @@ func (p *PPUMask) ToByte() byte {
 	return b
 }
 
+// GetEmphasis ... 色の強調設定（domain.EmphasizeRed などの組み合わせ）
+func (p *PPUMask) GetEmphasis() uint8 {
+	var e uint8
+	if p.EmphasizeR {
+		e = e | domain.EmphasizeRed
+	}
+	if p.EmphasizeG {
+		e = e | domain.EmphasizeGreen
+	}
+	if p.EmphasizeB {
+		e = e | domain.EmphasizeBlue
+	}
+	return e
+}
+
+// IsGrayscale ... グレースケール表示か
+func (p *PPUMask) IsGrayscale() bool {
+	return p.DisplayType == 1
+}
+
 // PPUStatus ...
 type PPUStatus struct {
 	VBlankHasStarted bool
@@ -393,6 +421,11 @@ func (p *PPUInternalRegisters) IncrementV(ctrl *PPUCtrl) {
 	}
 }
 
+// GetVRAMAddress ... 現在のVRAMアドレス(v)
+func (p *PPUInternalRegisters) GetVRAMAddress() domain.Address {
+	return domain.Address(p.v & 0x3FFF)
+}
+
 // GetTileIndexAddress ...
 func (p *PPUInternalRegisters) GetTileIndexAddress() domain.Address {
 	return domain.Address(domain.NameTableBaseAddress | (p.v & 0x0FFF))
@@ -429,3 +462,87 @@ func (p *PPUInternalRegisters) GetTilePatternHighAddress(tileIndex byte) domain.
 func (p *PPUInternalRegisters) GetFineX() byte {
 	return p.x
 }
+
+// PPURegistersState ... セーブステート用の状態
+type PPURegistersState struct {
+	PPUCtrl   PPUCtrl
+	PPUMask   PPUMask
+	PPUStatus PPUStatus
+	OAMAddr   byte
+	OAMData   byte
+	OAMDMA    byte
+
+	ScrollBuf      byte
+	ScrollBufValid bool
+	ScrollVOffset  byte
+	ScrollHOffset  byte
+
+	AddrWriteCount uint8
+	AddrBuf        domain.Address
+	AddrFull       domain.Address
+}
+
+// GetState ...
+func (r *PPURegisters) GetState() PPURegistersState {
+	s := PPURegistersState{
+		PPUCtrl:        *r.PPUCtrl,
+		PPUMask:        *r.PPUMask,
+		PPUStatus:      *r.PPUStatus,
+		OAMAddr:        r.OAMAddr,
+		OAMData:        r.OAMData,
+		OAMDMA:         r.OAMDMA,
+		ScrollVOffset:  r.PPUScroll.vOffset,
+		ScrollHOffset:  r.PPUScroll.hOffset,
+		AddrWriteCount: r.PPUAddr.writeCount,
+		AddrBuf:        r.PPUAddr.buf,
+		AddrFull:       r.PPUAddr.full,
+	}
+	if r.PPUScroll.buf != nil {
+		s.ScrollBuf = *r.PPUScroll.buf
+		s.ScrollBufValid = true
+	}
+	return s
+}
+
+// SetState ...
+func (r *PPURegisters) SetState(s PPURegistersState) {
+	*r.PPUCtrl = s.PPUCtrl
+	*r.PPUMask = s.PPUMask
+	*r.PPUStatus = s.PPUStatus
+	r.OAMAddr = s.OAMAddr
+	r.OAMData = s.OAMData
+	r.OAMDMA = s.OAMDMA
+
+	r.PPUScroll.buf = nil
+	if s.ScrollBufValid {
+		buf := s.ScrollBuf
+		r.PPUScroll.buf = &buf
+	}
+	r.PPUScroll.vOffset = s.ScrollVOffset
+	r.PPUScroll.hOffset = s.ScrollHOffset
+
+	r.PPUAddr.writeCount = s.AddrWriteCount
+	r.PPUAddr.buf = s.AddrBuf
+	r.PPUAddr.full = s.AddrFull
+}
+
+// PPUInternalRegistersState ... セーブステート用の状態
+type PPUInternalRegistersState struct {
+	V uint16
+	T uint16
+	X byte
+	W byte
+}
+
+// GetState ...
+func (p *PPUInternalRegisters) GetState() PPUInternalRegistersState {
+	return PPUInternalRegistersState{V: p.v, T: p.t, X: p.x, W: p.w}
+}
+
+// SetState ...
+func (p *PPUInternalRegisters) SetState(s PPUInternalRegistersState) {
+	p.v = s.V
+	p.t = s.T
+	p.x = s.X
+	p.w = s.W
+}
@@ -41,3 +41,14 @@ func (s *ShiftRegister8) Set(d byte) {
 func (s *ShiftRegister8) Shift() {
 	s.data = s.data >> 1
 }
+
+// GetState ... {high, low}
+func (s *ShiftRegister16bit) GetState() [2]byte {
+	return [2]byte{s.high, s.low}
+}
+
+// SetState ...
+func (s *ShiftRegister16bit) SetState(st [2]byte) {
+	s.high = st[0]
+	s.low = st[1]
+}
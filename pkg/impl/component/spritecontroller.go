@@ -4,8 +4,6 @@ import "nes-go/pkg/domain"
 
 import "nes-go/pkg/log"
 
-import "image/color"
-
 const (
 	MaxSpriteCount = 8
 	SpriteByteSize = 4 //単位はbyte
@@ -79,14 +77,14 @@ func (s *SpriteController) ClearSecondaryOAM(spriteIdx, byteIdx uint16) {
 }
 
 // EvaluateSprite ... 対象スプライトをセカンダリOAMにコピー
-func (s *SpriteController) EvaluateSprite(scanline uint16) {
+func (s *SpriteController) EvaluateSprite(scanline, spriteHeight uint16) {
 	if s.secondarySize >= 8 {
 		return
 	}
 
 	idx := s.n << 2
 	top := uint16(s.oam[idx]) + 1
-	btm := top + domain.SpriteHeight - 1
+	btm := top + spriteHeight - 1
 
 	var y uint16
 	if scanline <= 239 {
@@ -112,7 +110,8 @@ func (s *SpriteController) EvaluateSprite(scanline uint16) {
 }
 
 // FetchSprite ... セカンダリOAMからシフトレジスタ等へコピー
-func (s *SpriteController) FetchSprite(scanline uint16, patternTblIdx uint8) {
+// 8x16モードではパターンテーブルをタイル番号のbit0で選択し、上下2タイルを使う
+func (s *SpriteController) FetchSprite(scanline uint16, patternTblIdx uint8, spriteHeight uint16) {
 	if s.fetchedCount >= 8 {
 		return
 	}
@@ -128,9 +127,20 @@ func (s *SpriteController) FetchSprite(scanline uint16, patternTblIdx uint8) {
 		sprite := s.oam2[idx]
 		yOffset := scanline - uint16(sprite.Y)
 		if (sprite.Attribute & 0x80) == 0x80 {
-			yOffset = domain.SpriteHeight - yOffset
+			// 上下反転（8x16モードでは上下のタイルも入れ替わる）
+			yOffset = spriteHeight - 1 - yOffset
+		}
+
+		tileIndex := sprite.TileIndex
+		if spriteHeight == domain.LargeSpriteHeight {
+			patternTblIdx = tileIndex & 0x01
+			tileIndex = tileIndex & 0xFE
+			if yOffset >= domain.SpriteHeight {
+				tileIndex++
+				yOffset = yOffset - domain.SpriteHeight
+			}
 		}
-		pattern := s.bus.GetTilePattern(patternTblIdx, sprite.TileIndex)
+		pattern := s.bus.GetTilePattern(patternTblIdx, tileIndex)
 
 		if (sprite.Attribute & 0x40) == 0x40 {
 			s.patternRegisterL[idx].Set((*pattern)[yOffset])
@@ -159,8 +169,8 @@ func (s *SpriteController) Shift() {
 	}
 }
 
-// MakePixel ... ピクセルを生成し属性とともに返す
-func (s *SpriteController) MakePixel() (color.RGBA, byte) {
+// MakePixel ... ピクセルの色番号を属性とともに返す（透明な場合はopaqueがfalse）
+func (s *SpriteController) MakePixel() (colorIndex uint8, attr byte, opaque bool) {
 	for i := 0; i < 8; i++ {
 		counter := s.counters[i]
 		if counter != 0 {
@@ -174,14 +184,65 @@ func (s *SpriteController) MakePixel() (color.RGBA, byte) {
 			continue
 		}
 
-		attr := s.latches[i]
-		palette := s.bus.GetPalette(attr & 0x0F)
-
-		r, g, b := palette.GetColor(pattern)
+		attr = s.latches[i]
+		palette := s.bus.GetPalette(0x04 | (attr & 0x03))
 
-		return color.RGBA{R: r, G: g, B: b, A: 0xFF}, attr
+		return palette.GetColorIndex(pattern), attr, true
 	}
 
 	// 透明
-	return color.RGBA{R: 0, G: 0, B: 0, A: 0}, 0x00
+	return 0, 0x00, false
+}
+
+// SpriteControllerState ... セーブステート用の状態
+type SpriteControllerState struct {
+	OAM              []byte
+	LastWriteAddress uint8
+
+	OAM2             []domain.Sprite
+	PatternRegisterL []byte
+	PatternRegisterH []byte
+	Latches          []byte
+	Counters         []int16
+
+	N             uint8
+	SecondarySize uint8
+	FetchedCount  uint8
+}
+
+// GetState ...
+func (s *SpriteController) GetState() SpriteControllerState {
+	st := SpriteControllerState{
+		OAM:              append([]byte{}, s.oam...),
+		LastWriteAddress: s.lastWriteAddress,
+		OAM2:             append([]domain.Sprite{}, s.oam2...),
+		PatternRegisterL: make([]byte, MaxSpriteCount),
+		PatternRegisterH: make([]byte, MaxSpriteCount),
+		Latches:          append([]byte{}, s.latches...),
+		Counters:         append([]int16{}, s.counters...),
+		N:                s.n,
+		SecondarySize:    s.secondarySize,
+		FetchedCount:     s.fetchedCount,
+	}
+	for i := 0; i < MaxSpriteCount; i++ {
+		st.PatternRegisterL[i] = s.patternRegisterL[i].Get()
+		st.PatternRegisterH[i] = s.patternRegisterH[i].Get()
+	}
+	return st
+}
+
+// SetState ...
+func (s *SpriteController) SetState(st SpriteControllerState) {
+	copy(s.oam, st.OAM)
+	s.lastWriteAddress = st.LastWriteAddress
+	copy(s.oam2, st.OAM2)
+	for i := 0; i < MaxSpriteCount && i < len(st.PatternRegisterL) && i < len(st.PatternRegisterH); i++ {
+		s.patternRegisterL[i].Set(st.PatternRegisterL[i])
+		s.patternRegisterH[i].Set(st.PatternRegisterH[i])
+	}
+	copy(s.latches, st.Latches)
+	copy(s.counters, st.Counters)
+	s.n = st.N
+	s.secondarySize = st.SecondarySize
+	s.fetchedCount = st.FetchedCount
 }
@@ -0,0 +1,79 @@
+package component_test
+
+import (
+	"testing"
+
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl/component"
+	"nes-go/pkg/mock_domain"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestSpriteControllerFetchSprite(t *testing.T) {
+	tests := []struct {
+		name             string
+		spriteHeight     uint16
+		scanline         uint16
+		attribute        byte
+		wantPatternTable uint8
+		wantTileIndex    uint8
+	}{
+		{
+			name:             "When sprite is 8x8, pattern table is selected by PPUCTRL",
+			spriteHeight:     domain.SpriteHeight,
+			scanline:         12,
+			attribute:        0x00,
+			wantPatternTable: 0,
+			wantTileIndex:    0x13,
+		},
+		{
+			name:             "When sprite is 8x16 and top half, top tile is used",
+			spriteHeight:     domain.LargeSpriteHeight,
+			scanline:         12,
+			attribute:        0x00,
+			wantPatternTable: 1,
+			wantTileIndex:    0x12,
+		},
+		{
+			name:             "When sprite is 8x16 and bottom half, bottom tile is used",
+			spriteHeight:     domain.LargeSpriteHeight,
+			scanline:         20,
+			attribute:        0x00,
+			wantPatternTable: 1,
+			wantTileIndex:    0x13,
+		},
+		{
+			name:             "When sprite is 8x16 and flipped vertically, top and bottom tiles are swapped",
+			spriteHeight:     domain.LargeSpriteHeight,
+			scanline:         12,
+			attribute:        0x80,
+			wantPatternTable: 1,
+			wantTileIndex:    0x13,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			pattern := domain.TilePattern(make([]byte, 16))
+			bus := mock_domain.NewMockBus(ctrl)
+			bus.EXPECT().GetTilePattern(tt.wantPatternTable, tt.wantTileIndex).Return(&pattern).Times(1)
+
+			c := component.NewSpriteController()
+			c.SetBus(bus)
+
+			// Y座標=10, タイル番号=0x13
+			c.WriteOAM(0, 9)
+			c.WriteOAM(1, 0x13)
+			c.WriteOAM(2, tt.attribute)
+			c.WriteOAM(3, 0)
+
+			c.ClearSecondaryOAM(0, 0)
+			c.EvaluateSprite(tt.scanline, tt.spriteHeight)
+			c.FetchSprite(tt.scanline, 0, tt.spriteHeight)
+		})
+	}
+}
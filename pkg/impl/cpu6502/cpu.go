@@ -0,0 +1,429 @@
+package cpu6502
+
+import (
+	"fmt"
+
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl/component"
+	"nes-go/pkg/impl/instruction"
+	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
+)
+
+// Operand ...
+type Operand struct {
+	Data    *byte
+	Address *domain.Address
+}
+
+// String ...
+func (o *Operand) String() string {
+	d := ""
+	if o.Data != nil {
+		d = fmt.Sprintf("%#v", *o.Data)
+	}
+
+	a := ""
+	if o.Address != nil {
+		a = fmt.Sprintf("%#v", *o.Address)
+	}
+
+	return fmt.Sprintf("{Data:%#v, Address:%#v}", d, a)
+}
+
+// CPU ... 6502互換CPUのコア（メモリ空間はdomain.Memory経由でアクセス）
+type CPU struct {
+	registers   *component.CPURegisters
+	memory      domain.Memory
+	peek        func(domain.Address) (byte, error)
+	shouldReset bool
+	shouldNMI   bool
+	irqActive   bool // IRQの信号線（レベルトリガ）
+	jammed      bool // STP命令によりCPUが停止しているか
+
+	beforeNMIActive bool
+
+	firstPC    *uint16
+	executeLog *domain.Recorder
+
+	iFactory *instruction.Factory
+}
+
+// NewCPU ... pcを指定した場合はリセットベクタの代わりにpcから実行を開始する
+func NewCPU(variant domain.CPUVariant, pc *uint16) *CPU {
+	c := CPU{
+		registers:       component.NewCPURegisters(),
+		shouldReset:     true,
+		shouldNMI:       false,
+		irqActive:       false,
+		jammed:          false,
+		beforeNMIActive: false,
+		firstPC:         pc,
+		executeLog:      &domain.Recorder{},
+	}
+
+	f := instruction.Factory{
+		Registers: c.registers,
+		Variant:   variant,
+		Recorder:  c.executeLog,
+		Fetch:     c.fetch,
+		PushStack: c.pushStack,
+		PopStack:  c.popStack,
+		Jam:       c.jam,
+	}
+	c.iFactory = &f
+
+	return &c
+}
+
+// String ...
+func (c *CPU) String() string {
+	return fmt.Sprintf(
+		"CPU Info\nregisters: %v\nshould reset: %v",
+		c.registers.String(),
+		c.shouldReset,
+	)
+}
+
+// SetMemory ... peekは副作用なしでメモリを読み込む関数（nilの場合はmemory.Readを使う）
+func (c *CPU) SetMemory(m domain.Memory, peek func(domain.Address) (byte, error)) {
+	if peek == nil {
+		peek = m.Read
+	}
+	c.memory = m
+	c.peek = peek
+	c.iFactory.Memory = m
+	c.iFactory.Peek = peek
+}
+
+// SetRecorder ...
+func (c *CPU) SetRecorder(r *domain.Recorder) {
+	c.executeLog = r
+	c.iFactory.Recorder = r
+}
+
+// GetPC ...
+func (c *CPU) GetPC() uint16 {
+	return c.registers.PC
+}
+
+// GetRegisters ...
+func (c *CPU) GetRegisters() *component.CPURegisters {
+	return c.registers
+}
+
+// Run ... 1命令（もしくは割り込み処理）を実行し、消費したサイクル数を返す
+func (c *CPU) Run() (int, error) {
+	log.Trace("===== CPU RUN =====")
+	log.Trace(c.String())
+
+	c.executeLog.PC = c.registers.PC
+	c.executeLog.FetchedValue = nil
+	c.executeLog.Mnemonic = domain.NOP
+	c.executeLog.Documented = false
+	c.executeLog.AddressingMode = domain.Implied
+	c.executeLog.Address = nil
+	c.executeLog.Data = nil
+	c.executeLog.A = c.registers.A
+	c.executeLog.X = c.registers.X
+	c.executeLog.Y = c.registers.Y
+	c.executeLog.P = c.registers.P.ToByte()
+	c.executeLog.SP = c.registers.S
+
+	if c.shouldReset {
+		if err := c.interruptRESET(); err != nil {
+			return 0, xerrors.Errorf(": %w", err)
+		}
+		return 7, nil
+	}
+
+	if c.jammed {
+		// 停止中はリセット以外では復帰しない
+		return 1, nil
+	}
+
+	if c.shouldNMI {
+		if err := c.InterruptNMI(); err != nil {
+			return 0, xerrors.Errorf(": %w", err)
+		}
+		return 7, nil
+	}
+
+	if c.irqActive && !c.registers.P.InterruptDisable {
+		if err := c.interruptIRQ(); err != nil {
+			return 0, xerrors.Errorf(": %w", err)
+		}
+		return 7, nil
+	}
+
+	// PC（プログラムカウンタ）からオペコードをフェッチ（PCをインクリメント）
+	oc, err := c.fetchOpcode()
+	if err != nil {
+		return 0, xerrors.Errorf(": %w", err)
+	}
+
+	// 命令とアドレッシング・モードを判別
+	ocp, err := decodeOpcode(oc)
+	if err != nil {
+		return 0, xerrors.Errorf(": %w", err)
+	}
+
+	instruction := c.iFactory.Make(ocp)
+
+	// （必要であれば）オペランドをフェッチ（PCをインクリメント）
+	op, err := instruction.FetchAsOperand()
+	if err != nil {
+		return 0, xerrors.Errorf(": %w", err)
+	}
+
+	// 命令を実行
+	if cycle, err := instruction.Execute(op); err != nil {
+		return 0, xerrors.Errorf(": %w", err)
+	} else {
+		return cycle, nil
+	}
+}
+
+// decodeOpcode ...
+func decodeOpcode(o domain.Opcode) (*domain.OpcodeProp, error) {
+	if p, ok := domain.OpcodeProps[o]; ok {
+		log.Trace("begin[opcode=%#v] => %#v", o, p)
+		return &p, nil
+	}
+	log.Trace("begin[%#v] => not found", o)
+	return nil, xerrors.Errorf("opcode: %#v: %w", o, domain.ErrUnsupportedOpcode)
+}
+
+// fetch ...
+func (c *CPU) fetch() (byte, error) {
+	var addr domain.Address
+	var data byte
+	var err error
+
+	log.Trace("begin ...")
+	defer func() {
+		if err != nil {
+			log.Warn("end[addr=%#v] => error %#v", addr, err)
+		} else {
+			log.Trace("end[addr=%#v] => %#v", addr, data)
+		}
+	}()
+
+	addr = domain.Address(c.registers.PC)
+	data, err = c.memory.Read(addr)
+	if err != nil {
+		return data, xerrors.Errorf("failed to fetch: %w", err)
+	}
+
+	c.registers.IncrementPC()
+
+	c.executeLog.FetchedValue = append(c.executeLog.FetchedValue, data)
+
+	return data, nil
+}
+
+// fetchOpcode ...
+func (c *CPU) fetchOpcode() (domain.Opcode, error) {
+	v, err := c.fetch()
+	if err != nil {
+		return domain.ErrorOpcode, xerrors.Errorf(": %w", err)
+	}
+	return domain.Opcode(v), nil
+}
+
+// interrupt ... PCとPをスタックに退避し、ベクタの示すアドレスへジャンプ
+func (c *CPU) interrupt(vector domain.Address) error {
+	if err := c.pushStack(byte((c.registers.PC & 0xFF00) >> 8)); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	if err := c.pushStack(byte(c.registers.PC & 0x00FF)); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	// スタックに格納するフラグはBフラグがクリアされた状態になる
+	p := (c.registers.P.ToByte() & 0xEF) | 0x20
+	if err := c.pushStack(p); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	c.registers.P.UpdateI(true)
+
+	l, err := c.memory.Read(vector)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	h, err := c.memory.Read(vector + 1)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	c.registers.UpdatePC((uint16(h) << 8) | uint16(l))
+	return nil
+}
+
+// InterruptNMI ...
+func (c *CPU) InterruptNMI() error {
+	log.Trace("begin[Interrupt NMI] ...")
+	defer log.Trace("end[Interrupt NMI]")
+
+	if err := c.interrupt(0xFFFA); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	c.shouldNMI = false
+	return nil
+}
+
+// interruptRESET ...
+func (c *CPU) interruptRESET() error {
+	log.Trace("begin[Interrupt RESET] ...")
+	defer log.Trace("end[Interrupt RESET]")
+
+	// リセットシーケンスはスタックへの書き込みを行わずにSを3減らす
+	c.registers.UpdateS(c.registers.S - 3)
+	c.registers.P.UpdateI(true)
+
+	if c.firstPC != nil {
+		c.registers.UpdatePC(*c.firstPC)
+	} else {
+		l, err := c.memory.Read(0xFFFC)
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+
+		h, err := c.memory.Read(0xFFFD)
+		if err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+
+		c.registers.UpdatePC((uint16(h) << 8) | uint16(l))
+	}
+
+	c.shouldReset = false
+	c.jammed = false
+	return nil
+}
+
+// PowerOn ... 電源投入時の状態にする（次のRunでリセットシーケンスを実行する）
+func (c *CPU) PowerOn(p domain.PowerOnProfile) {
+	c.registers.UpdateA(p.A)
+	c.registers.UpdateX(p.X)
+	c.registers.UpdateY(p.Y)
+	c.registers.UpdateS(p.S)
+	c.registers.P.UpdateAll(p.P | 0x20) // bit5は常に1
+	c.registers.UpdatePC(0)
+
+	c.shouldNMI = false
+	c.irqActive = false
+	c.beforeNMIActive = false
+	c.Reset()
+}
+
+// Reset ... RESET信号を入力する（次のRunでリセットベクタから実行を再開する）
+func (c *CPU) Reset() {
+	c.shouldReset = true
+}
+
+// interruptIRQ ...
+func (c *CPU) interruptIRQ() error {
+	log.Trace("begin[Interrupt IRQ] ...")
+	defer log.Trace("end[Interrupt IRQ]")
+
+	if err := c.interrupt(0xFFFE); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// jam ... CPUを停止状態にする
+func (c *CPU) jam() {
+	log.Warn("CPU jammed; PC: %#v", c.registers.PC)
+	c.jammed = true
+}
+
+// IsJammed ... STP命令によりCPUが停止しているか
+func (c *CPU) IsJammed() bool {
+	return c.jammed
+}
+
+// ReceiveNMI ...
+func (c *CPU) ReceiveNMI(active bool) {
+	log.Trace("begin[%v] ...", active)
+	defer log.Trace("end[%v]", active)
+	if !c.beforeNMIActive && active {
+		// activeが　false => true となったときにNMI割り込みを発生
+		c.shouldNMI = true
+	}
+	c.beforeNMIActive = active
+}
+
+// ReceiveIRQ ... IRQの信号線を更新（activeの間はIフラグがクリアされていれば割り込みが発生する）
+func (c *CPU) ReceiveIRQ(active bool) {
+	c.irqActive = active
+}
+
+// pushStack ...
+func (c *CPU) pushStack(b byte) error {
+	addr := domain.Address(uint16(0x0100) | uint16(c.registers.S))
+	err := c.memory.Write(addr, b)
+	if err != nil {
+		err = xerrors.Errorf(": %w", err)
+	}
+	c.registers.S--
+	log.Trace("CPU.pushStack[%2X] => [addr=%v]", b, addr)
+	return err
+}
+
+// popStack ...
+func (c *CPU) popStack() (byte, error) {
+	c.registers.S++
+	addr := domain.Address(uint16(0x0100) | uint16(c.registers.S))
+	b, err := c.memory.Read(addr)
+	if err != nil {
+		err = xerrors.Errorf(": %w", err)
+	}
+	log.Trace("CPU.popStack[%2X] <= [addr=%v]", b, addr)
+	return b, err
+}
+
+// cpuState ... セーブステート用の状態
+type cpuState struct {
+	Registers       component.CPURegistersState
+	ShouldReset     bool
+	ShouldNMI       bool
+	IRQActive       bool
+	Jammed          bool
+	BeforeNMIActive bool
+}
+
+// MarshalState ...
+func (c *CPU) MarshalState() ([]byte, error) {
+	data, err := domain.EncodeState(cpuState{
+		Registers:       c.registers.GetState(),
+		ShouldReset:     c.shouldReset,
+		ShouldNMI:       c.shouldNMI,
+		IRQActive:       c.irqActive,
+		Jammed:          c.jammed,
+		BeforeNMIActive: c.beforeNMIActive,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (c *CPU) UnmarshalState(data []byte) error {
+	s := cpuState{}
+	if err := domain.DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	c.registers.SetState(s.Registers)
+	c.shouldReset = s.ShouldReset
+	c.shouldNMI = s.ShouldNMI
+	c.irqActive = s.IRQActive
+	c.jammed = s.Jammed
+	c.beforeNMIActive = s.BeforeNMIActive
+	return nil
+}
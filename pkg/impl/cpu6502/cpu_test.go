@@ -0,0 +1,294 @@
+package cpu6502_test
+
+import (
+	"fmt"
+	"testing"
+
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl/cpu6502"
+)
+
+// setup ... 0x0200からprogramを配置したCPUを生成（リセット済）
+func setup(t *testing.T, variant domain.CPUVariant, program []byte) (*cpu6502.CPU, *cpu6502.RAM) {
+	t.Helper()
+
+	ram := cpu6502.NewRAM()
+	ram.Load(0x0200, program)
+	// IRQ/BRKベクタ
+	ram.Load(0xFFFE, []byte{0x00, 0x03})
+
+	pc := uint16(0x0200)
+	c := cpu6502.NewCPU(variant, &pc)
+	c.SetMemory(ram, nil)
+	if _, err := c.Run(); err != nil {
+		t.Fatalf("failed to reset: %v", err)
+	}
+	return c, ram
+}
+
+// runSteps ... 指定した命令数だけ実行
+func runSteps(t *testing.T, c *cpu6502.CPU, steps int) int {
+	t.Helper()
+
+	total := 0
+	for i := 0; i < steps; i++ {
+		cycle, err := c.Run()
+		if err != nil {
+			t.Fatalf("failed to run: %v", err)
+		}
+		total = total + cycle
+	}
+	return total
+}
+
+func TestCPUDecimalMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		variant   domain.CPUVariant
+		program   []byte
+		wantA     byte
+		wantCarry bool
+	}{
+		{
+			name:    "when variant is NMOS 6502, ADC is BCD",
+			variant: domain.CPUVariantNMOS6502,
+			// SED; CLC; LDA #$19; ADC #$28
+			program:   []byte{0xF8, 0x18, 0xA9, 0x19, 0x69, 0x28},
+			wantA:     0x47,
+			wantCarry: false,
+		},
+		{
+			name:    "when variant is NMOS 6502, ADC carries over 99",
+			variant: domain.CPUVariantNMOS6502,
+			// SED; CLC; LDA #$58; ADC #$46
+			program:   []byte{0xF8, 0x18, 0xA9, 0x58, 0x69, 0x46},
+			wantA:     0x04,
+			wantCarry: true,
+		},
+		{
+			name:    "when variant is NMOS 6502, SBC is BCD",
+			variant: domain.CPUVariantNMOS6502,
+			// SED; SEC; LDA #$42; SBC #$13
+			program:   []byte{0xF8, 0x38, 0xA9, 0x42, 0xE9, 0x13},
+			wantA:     0x29,
+			wantCarry: true,
+		},
+		{
+			name:    "when variant is 2A03, D flag is ignored on ADC",
+			variant: domain.CPUVariant2A03,
+			// SED; CLC; LDA #$19; ADC #$28
+			program:   []byte{0xF8, 0x18, 0xA9, 0x19, 0x69, 0x28},
+			wantA:     0x41,
+			wantCarry: false,
+		},
+		{
+			name:    "when variant is 2A03, D flag is ignored on SBC",
+			variant: domain.CPUVariant2A03,
+			// SED; SEC; LDA #$42; SBC #$13
+			program:   []byte{0xF8, 0x38, 0xA9, 0x42, 0xE9, 0x13},
+			wantA:     0x2F,
+			wantCarry: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := setup(t, tt.variant, tt.program)
+			runSteps(t, c, 4)
+
+			r := c.GetRegisters()
+			if r.A != tt.wantA || r.P.Carry != tt.wantCarry {
+				t.Errorf("wrong output\nwant: A=%#v, C=%#v\ngot: A=%#v, C=%#v", tt.wantA, tt.wantCarry, r.A, r.P.Carry)
+			}
+		})
+	}
+}
+
+func TestCPUBRK(t *testing.T) {
+	// BRK; (padding); LDA #$01
+	c, ram := setup(t, domain.CPUVariantNMOS6502, []byte{0x00, 0xFF, 0xA9, 0x01})
+	// 0x0300: RTI
+	ram.Load(0x0300, []byte{0x40})
+
+	if cycle := runSteps(t, c, 1); cycle != 7 {
+		t.Errorf("wrong cycle\nwant: %#v\ngot: %#v", 7, cycle)
+	}
+	if c.GetPC() != 0x0300 {
+		t.Fatalf("wrong PC after BRK\nwant: %#v\ngot: %#v", 0x0300, c.GetPC())
+	}
+	p, _ := ram.Read(0x01FB)
+	if (p & 0x10) != 0x10 {
+		t.Errorf("B flag is not pushed; P: %#v", p)
+	}
+
+	runSteps(t, c, 1)
+	if c.GetPC() != 0x0202 {
+		t.Errorf("wrong PC after RTI\nwant: %#v\ngot: %#v", 0x0202, c.GetPC())
+	}
+}
+
+func TestCPUIRQ(t *testing.T) {
+	// CLI; NOP
+	c, ram := setup(t, domain.CPUVariantNMOS6502, []byte{0x58, 0xEA})
+	c.ReceiveIRQ(true)
+
+	runSteps(t, c, 1)
+	if cycle := runSteps(t, c, 1); cycle != 7 {
+		t.Errorf("wrong cycle\nwant: %#v\ngot: %#v", 7, cycle)
+	}
+	if c.GetPC() != 0x0300 {
+		t.Fatalf("wrong PC after IRQ\nwant: %#v\ngot: %#v", 0x0300, c.GetPC())
+	}
+	p, _ := ram.Read(0x01FB)
+	if (p & 0x10) != 0x00 {
+		t.Errorf("B flag is pushed; P: %#v", p)
+	}
+	if !c.GetRegisters().P.InterruptDisable {
+		t.Errorf("I flag is not set")
+	}
+}
+
+func TestCPUSTP(t *testing.T) {
+	opcodes := []byte{0x02, 0x12, 0x22, 0x32, 0x42, 0x52, 0x62, 0x72, 0x92, 0xB2, 0xD2, 0xF2}
+
+	for _, op := range opcodes {
+		t.Run(fmt.Sprintf("when opcode is %#02X, CPU is jammed", op), func(t *testing.T) {
+			// STP; LDA #$01
+			c, _ := setup(t, domain.CPUVariantNMOS6502, []byte{op, 0xA9, 0x01})
+			if c.IsJammed() {
+				t.Fatalf("CPU is jammed before STP")
+			}
+			runSteps(t, c, 3)
+
+			if !c.IsJammed() {
+				t.Fatalf("CPU is not jammed")
+			}
+			if c.GetRegisters().A != 0x00 {
+				t.Errorf("instruction is executed after STP; A: %#v", c.GetRegisters().A)
+			}
+			if c.GetPC() != 0x0201 {
+				t.Errorf("wrong PC\nwant: %#v\ngot: %#v", 0x0201, c.GetPC())
+			}
+		})
+	}
+}
+
+func TestCPUUnofficialImmediate(t *testing.T) {
+	type flags struct {
+		N, V, Z, C bool
+	}
+
+	tests := []struct {
+		name      string
+		program   []byte
+		steps     int
+		wantA     byte
+		wantX     byte
+		wantFlags flags
+	}{
+		{
+			name: "when ANC result has bit7, C and N are set",
+			// LDA #$FF; ANC #$80
+			program:   []byte{0xA9, 0xFF, 0x0B, 0x80},
+			steps:     2,
+			wantA:     0x80,
+			wantFlags: flags{N: true, C: true},
+		},
+		{
+			name: "when ANC result is zero, Z is set and C is cleared",
+			// SEC; LDA #$FE; ANC #$01
+			program:   []byte{0x38, 0xA9, 0xFE, 0x0B, 0x01},
+			steps:     3,
+			wantA:     0x00,
+			wantFlags: flags{Z: true},
+		},
+		{
+			name: "when ANC is 0x2B, it behaves as 0x0B",
+			// SEC; LDA #$C0; ANC #$40
+			program:   []byte{0x38, 0xA9, 0xC0, 0x2B, 0x40},
+			steps:     3,
+			wantA:     0x40,
+			wantFlags: flags{},
+		},
+		{
+			name: "when ALR shifts out bit0, C is set",
+			// LDA #$03; ALR #$FF
+			program:   []byte{0xA9, 0x03, 0x4B, 0xFF},
+			steps:     2,
+			wantA:     0x01,
+			wantFlags: flags{C: true},
+		},
+		{
+			name: "when ARR rotates carry in, N and C are set from the result",
+			// SEC; LDA #$FF; ARR #$FF
+			program:   []byte{0x38, 0xA9, 0xFF, 0x6B, 0xFF},
+			steps:     3,
+			wantA:     0xFF,
+			wantFlags: flags{N: true, C: true},
+		},
+		{
+			name: "when ARR result has bit6 and not bit5, C and V are set",
+			// CLC; LDA #$80; ARR #$FF
+			program:   []byte{0x18, 0xA9, 0x80, 0x6B, 0xFF},
+			steps:     3,
+			wantA:     0x40,
+			wantFlags: flags{V: true, C: true},
+		},
+		{
+			name: "when ARR result has bit5 and not bit6, only V is set",
+			// CLC; LDA #$40; ARR #$FF
+			program:   []byte{0x18, 0xA9, 0x40, 0x6B, 0xFF},
+			steps:     3,
+			wantA:     0x20,
+			wantFlags: flags{V: true},
+		},
+		{
+			name: "when ARR result is zero, Z is set",
+			// CLC; LDA #$01; ARR #$01
+			program:   []byte{0x18, 0xA9, 0x01, 0x6B, 0x01},
+			steps:     3,
+			wantA:     0x00,
+			wantFlags: flags{Z: true},
+		},
+		{
+			name: "when AXS does not borrow, X is (A AND X) - imm and C is set",
+			// LDA #$F0; LDX #$3C; AXS #$10
+			program:   []byte{0xA9, 0xF0, 0xA2, 0x3C, 0xCB, 0x10},
+			steps:     3,
+			wantA:     0xF0,
+			wantX:     0x20,
+			wantFlags: flags{C: true},
+		},
+		{
+			name: "when AXS borrows, C is cleared regardless of the previous carry",
+			// SEC; LDA #$F0; LDX #$3C; AXS #$31
+			program:   []byte{0x38, 0xA9, 0xF0, 0xA2, 0x3C, 0xCB, 0x31},
+			steps:     4,
+			wantA:     0xF0,
+			wantX:     0xFF,
+			wantFlags: flags{N: true},
+		},
+		{
+			name: "when AXS result is zero, Z and C are set regardless of the previous carry",
+			// CLC; LDA #$F0; LDX #$3C; AXS #$30
+			program:   []byte{0x18, 0xA9, 0xF0, 0xA2, 0x3C, 0xCB, 0x30},
+			steps:     4,
+			wantA:     0xF0,
+			wantX:     0x00,
+			wantFlags: flags{Z: true, C: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := setup(t, domain.CPUVariant2A03, tt.program)
+			runSteps(t, c, tt.steps)
+
+			r := c.GetRegisters()
+			got := flags{N: r.P.Negative, V: r.P.Overflow, Z: r.P.Zero, C: r.P.Carry}
+			if r.A != tt.wantA || r.X != tt.wantX || got != tt.wantFlags {
+				t.Errorf("wrong output\nwant: A=%#v, X=%#v, %+v\ngot: A=%#v, X=%#v, %+v", tt.wantA, tt.wantX, tt.wantFlags, r.A, r.X, got)
+			}
+		})
+	}
+}
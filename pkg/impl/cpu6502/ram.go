@@ -0,0 +1,35 @@
+package cpu6502
+
+import (
+	"nes-go/pkg/domain"
+)
+
+// RAM ... 64KBのフラットなメモリ空間
+type RAM struct {
+	data []byte
+}
+
+// NewRAM ...
+func NewRAM() *RAM {
+	return &RAM{
+		data: make([]byte, 0x10000),
+	}
+}
+
+// Load ... addrから順にdataを書き込む（64KBを超える分は先頭に折り返す）
+func (r *RAM) Load(addr domain.Address, data []byte) {
+	for i, b := range data {
+		r.data[(int(addr)+i)&0xFFFF] = b
+	}
+}
+
+// Read ...
+func (r *RAM) Read(addr domain.Address) (byte, error) {
+	return r.data[addr], nil
+}
+
+// Write ...
+func (r *RAM) Write(addr domain.Address, data byte) error {
+	r.data[addr] = data
+	return nil
+}
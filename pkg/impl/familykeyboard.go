@@ -0,0 +1,250 @@
+package impl
+
+import (
+	"nes-go/pkg/domain"
+	"nes-go/pkg/log"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/inpututil"
+	"golang.org/x/xerrors"
+)
+
+// captureKey ... ホストのキーボードをファミリーベーシックのキーボードとして使うかを切り替えるキー
+const captureKey = ebiten.KeyF12
+
+// KeyboardCapture ... ホストのキーボードの入力先（ファミリーベーシックのキーボードか、パッド・ホットキーか）
+// 入力先を切り替えるUpdateはUIのgoroutine、IsCapturedはエミュレーションのgoroutineからも呼ぶ
+type KeyboardCapture struct {
+	mu       sync.Mutex
+	captured bool
+}
+
+// NewKeyboardCapture ...
+func NewKeyboardCapture(captured bool) *KeyboardCapture {
+	return &KeyboardCapture{
+		captured: captured,
+	}
+}
+
+// IsCaptured ... ファミリーベーシックのキーボードが入力を使っているか
+func (c *KeyboardCapture) IsCaptured() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.captured
+}
+
+// Update ... Renderer.AddUpdateHookに登録して使う
+// F12: ファミリーベーシックのキーボードとパッド・ホットキーを切り替える
+func (c *KeyboardCapture) Update() error {
+	if !inpututil.IsKeyJustPressed(captureKey) {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.captured = !c.captured
+	if c.captured {
+		log.Info("keyboard: family basic keyboard")
+	} else {
+		log.Info("keyboard: pads and hotkeys")
+	}
+	return nil
+}
+
+// Filter ... キーボードを使っている間はhookを呼ばない（ホットキーを止める）
+func (c *KeyboardCapture) Filter(hook func() error) func() error {
+	return func() error {
+		if c.IsCaptured() {
+			return nil
+		}
+		return hook()
+	}
+}
+
+// WrapPad ... キーボードを使っている間はどのボタンも押していないことにするPad
+func (c *KeyboardCapture) WrapPad(p domain.Pad) domain.Pad {
+	return &capturedPad{pad: p, capture: c}
+}
+
+// capturedPad ...
+type capturedPad struct {
+	pad      domain.Pad
+	capture  *KeyboardCapture
+	captured bool
+}
+
+// Load ...
+func (p *capturedPad) Load() error {
+	p.captured = p.capture.IsCaptured()
+	if err := p.pad.Load(); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
+// IsPressed ...
+func (p *capturedPad) IsPressed(b domain.ButtonType) bool {
+	return !p.captured && p.pad.IsPressed(b)
+}
+
+// FamilyKeyboardMapping ... ファミリーベーシックのキーごとのホストのキーの割り当て
+type FamilyKeyboardMapping map[domain.KeyboardKey][]ebiten.Key
+
+// defaultFamilyKeyboardNames ... 英数字・F1-F8・矢印キーなどは同じ名前のキーに割り当てる（RSHIFTは割り当てない）
+var defaultFamilyKeyboardNames = map[domain.KeyboardKey][]string{
+	domain.KeyboardKeyReturn:    {"Enter", "KPEnter"},
+	domain.KeyboardKeySpace:     {"Space"},
+	domain.KeyboardKeyLeftShift: {"Shift"},
+	"CTR":                       {"Control"},
+	"GRPH":                      {"Alt"},
+	"KANA":                      {"Tab"},
+	"ESC":                       {"Escape"},
+	"STOP":                      {"End"},
+	"CLR":                       {"Home"},
+	"INS":                       {"Insert"},
+	"DEL":                       {"Backspace", "Delete"},
+	"[":                         {"LeftBracket"},
+	"]":                         {"RightBracket"},
+	";":                         {"Semicolon"},
+	":":                         {"Apostrophe"},
+	"@":                         {"GraveAccent"},
+	"^":                         {"Equal"},
+	"¥":                         {"Backslash"},
+	"-":                         {"Minus"},
+	"/":                         {"Slash"},
+	",":                         {"Comma"},
+	".":                         {"Period"},
+}
+
+// DefaultFamilyKeyboardNames ... デフォルトの割り当て（キー名: ホストのキー名）
+func DefaultFamilyKeyboardNames() map[string][]string {
+	names := map[string][]string{}
+	for _, k := range domain.KeyboardKeyList() {
+		if keys, ok := defaultFamilyKeyboardNames[k]; ok {
+			names[string(k)] = append([]string{}, keys...)
+			continue
+		}
+		if _, err := ParseKey(string(k)); err == nil {
+			names[string(k)] = []string{string(k)}
+		}
+	}
+	return names
+}
+
+// ParseFamilyKeyboardMapping ... キー名とホストのキー名の割り当てからFamilyKeyboardMappingを作る
+// 1つのホストのキーを複数のキーに割り当てたり、切り替えのキー（F12）を割り当てた場合はエラー
+func ParseFamilyKeyboardMapping(names map[string][]string) (FamilyKeyboardMapping, error) {
+	m := FamilyKeyboardMapping{}
+	used := map[ebiten.Key]domain.KeyboardKey{}
+	for key, hostKeys := range names {
+		k, err := domain.ParseKeyboardKey(key)
+		if err != nil {
+			return nil, xerrors.Errorf(": %w", err)
+		}
+		for _, name := range hostKeys {
+			hk, err := ParseKey(name)
+			if err != nil {
+				return nil, xerrors.Errorf("keyboard key %v: %w", k, err)
+			}
+			if hk == captureKey {
+				return nil, xerrors.Errorf("keyboard key %v: %v is used to switch the keyboard", k, hk)
+			}
+			if prev, ok := used[hk]; ok && prev != k {
+				return nil, xerrors.Errorf("key %v is assigned to both keyboard %v and %v", hk, prev, k)
+			}
+			used[hk] = k
+			m[k] = append(m[k], hk)
+		}
+	}
+	return m, nil
+}
+
+// FamilyKeyboardInput ... ホストのキーボードで操作するファミリーベーシックのキーボード
+// テキスト入力モードでは、ホストで入力した文字を対応するキーの組み合わせで1文字ずつ打つ
+// （文字にならないRETURNやDEL、矢印キーなどは割り当てたキーで操作する）
+type FamilyKeyboardInput struct {
+	mapping  FamilyKeyboardMapping
+	capture  *KeyboardCapture
+	textMode bool
+
+	mu      sync.Mutex
+	pending []rune // UIのgoroutineで受け取り、まだ打っていない文字
+
+	typer   *domain.KeyboardTyper
+	pressed map[domain.KeyboardKey]bool
+}
+
+// NewFamilyKeyboardInput ...
+func NewFamilyKeyboardInput(mapping FamilyKeyboardMapping, capture *KeyboardCapture, textMode bool) *FamilyKeyboardInput {
+	return &FamilyKeyboardInput{
+		mapping:  mapping,
+		capture:  capture,
+		textMode: textMode,
+		pending:  []rune{},
+		typer:    domain.NewKeyboardTyper(),
+		pressed:  map[domain.KeyboardKey]bool{},
+	}
+}
+
+// Update ... Renderer.AddUpdateHookに登録して使う（テキスト入力モードで入力した文字を受け取る）
+func (f *FamilyKeyboardInput) Update() error {
+	if !f.textMode || !f.capture.IsCaptured() {
+		return nil
+	}
+	chars := ebiten.InputChars()
+	if len(chars) == 0 {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.pending = append(f.pending, chars...)
+	return nil
+}
+
+// Load ...
+func (f *FamilyKeyboardInput) Load() error {
+	for k := range f.pressed {
+		delete(f.pressed, k)
+	}
+	if !f.capture.IsCaptured() {
+		return nil
+	}
+
+	for k, hostKeys := range f.mapping {
+		if f.textMode && isTextKey(k) {
+			continue
+		}
+		for _, hk := range hostKeys {
+			if ebiten.IsKeyPressed(hk) {
+				f.pressed[k] = true
+			}
+		}
+	}
+
+	if f.textMode {
+		f.mu.Lock()
+		f.typer.Type(string(f.pending))
+		f.pending = f.pending[:0]
+		f.mu.Unlock()
+
+		for _, k := range f.typer.Step() {
+			f.pressed[k] = true
+		}
+	}
+	return nil
+}
+
+// isTextKey ... テキスト入力モードで文字として入力するキー（SHIFTを含む）
+func isTextKey(k domain.KeyboardKey) bool {
+	return k == domain.KeyboardKeySpace || k == domain.KeyboardKeyLeftShift || len([]rune(string(k))) == 1
+}
+
+// IsKeyPressed ...
+func (f *FamilyKeyboardInput) IsKeyPressed(k domain.KeyboardKey) bool {
+	return f.pressed[k]
+}
@@ -0,0 +1,148 @@
+package impl
+
+import (
+	"nes-go/pkg/domain"
+	"nes-go/pkg/log"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten"
+	"golang.org/x/xerrors"
+)
+
+// DefaultGamepadDeadzone ... アナログスティックを倒したとみなす割合のデフォルト
+const DefaultGamepadDeadzone = 0.4
+
+// GamepadAxis ... アナログスティックなどの軸とボタンの割り当て
+type GamepadAxis struct {
+	Axis     int
+	Negative domain.ButtonType // 負の方向に倒したときに押すボタン（空の場合は何もしない）
+	Positive domain.ButtonType // 正の方向に倒したときに押すボタン（空の場合は何もしない）
+}
+
+// GamepadProfile ... ゲームパッドのボタン・軸の割り当て
+type GamepadProfile struct {
+	Buttons  map[domain.ButtonType][]ebiten.GamepadButton
+	Axes     []GamepadAxis
+	Deadzone float64 // 軸の値の絶対値がこれより大きい場合にボタンを押したことにする
+}
+
+// StandardGamepadProfile ... XInput互換のゲームパッドの割り当て（GLFWのボタン番号）
+// A: 右のボタン, B: 下のボタン, 連射A: 上のボタン, 連射B: 左のボタン, SELECT: Back, START: Start, 十字キーと左スティックで上下左右
+func StandardGamepadProfile() GamepadProfile {
+	return GamepadProfile{
+		Buttons: map[domain.ButtonType][]ebiten.GamepadButton{
+			domain.ButtonTypeA:      {ebiten.GamepadButton1},
+			domain.ButtonTypeB:      {ebiten.GamepadButton0},
+			domain.ButtonTypeSelect: {ebiten.GamepadButton6},
+			domain.ButtonTypeStart:  {ebiten.GamepadButton7},
+			domain.ButtonTypeUp:     {ebiten.GamepadButton10},
+			domain.ButtonTypeRight:  {ebiten.GamepadButton11},
+			domain.ButtonTypeDown:   {ebiten.GamepadButton12},
+			domain.ButtonTypeLeft:   {ebiten.GamepadButton13},
+			domain.ButtonTypeTurboA: {ebiten.GamepadButton3},
+			domain.ButtonTypeTurboB: {ebiten.GamepadButton2},
+		},
+		Axes: []GamepadAxis{
+			{Axis: 0, Negative: domain.ButtonTypeLeft, Positive: domain.ButtonTypeRight},
+			{Axis: 1, Negative: domain.ButtonTypeUp, Positive: domain.ButtonTypeDown},
+		},
+		Deadzone: DefaultGamepadDeadzone,
+	}
+}
+
+// Validate ...
+func (p GamepadProfile) Validate() error {
+	for b, buttons := range p.Buttons {
+		for _, gb := range buttons {
+			if gb < ebiten.GamepadButton0 || gb > ebiten.GamepadButtonMax {
+				return xerrors.Errorf("button %v: invalid gamepad button: %v", b, int(gb))
+			}
+		}
+	}
+	for _, a := range p.Axes {
+		if a.Axis < 0 {
+			return xerrors.Errorf("invalid gamepad axis: %v", a.Axis)
+		}
+	}
+	if p.Deadzone < 0 || p.Deadzone >= 1 {
+		return xerrors.Errorf("deadzone must be in [0, 1): %v", p.Deadzone)
+	}
+	return nil
+}
+
+// GamepadPad ... ゲームパッドを使うPad
+// ebitenではゲームパッドを名前で区別できないため、接続されている順番（device）で指定する
+// 毎フレーム接続を確認するので、抜き差ししても再設定は不要
+type GamepadPad struct {
+	device  int
+	profile GamepadProfile
+
+	id        int // 接続中のゲームパッドのebitenのID
+	connected bool
+	states    map[domain.ButtonType]bool
+}
+
+// NewGamepadPad ...
+func NewGamepadPad(device int, profile GamepadProfile) *GamepadPad {
+	return &GamepadPad{
+		device:  device,
+		profile: profile,
+		states:  map[domain.ButtonType]bool{},
+	}
+}
+
+// Load ...
+func (g *GamepadPad) Load() error {
+	for _, b := range domain.ButtonList {
+		g.states[b] = false
+	}
+
+	ids := ebiten.GamepadIDs()
+	sort.Ints(ids)
+
+	connected := g.device < len(ids)
+	if !connected {
+		if g.connected {
+			log.Info("gamepad[device=%v] disconnected", g.device)
+		}
+		g.connected = false
+		return nil
+	}
+
+	id := ids[g.device]
+	if !g.connected || g.id != id {
+		log.Info("gamepad[device=%v] connected: id=%v, buttons=%v, axes=%v", g.device, id, ebiten.GamepadButtonNum(id), ebiten.GamepadAxisNum(id))
+	}
+	g.connected = true
+	g.id = id
+
+	buttonNum := ebiten.GamepadButtonNum(id)
+	for b, buttons := range g.profile.Buttons {
+		for _, gb := range buttons {
+			if int(gb) < buttonNum && ebiten.IsGamepadButtonPressed(id, gb) {
+				g.states[b] = true
+			}
+		}
+	}
+
+	axisNum := ebiten.GamepadAxisNum(id)
+	for _, a := range g.profile.Axes {
+		if a.Axis >= axisNum {
+			continue
+		}
+		v := ebiten.GamepadAxis(id, a.Axis)
+		if v < -g.profile.Deadzone && a.Negative != "" {
+			g.states[a.Negative] = true
+		}
+		if v > g.profile.Deadzone && a.Positive != "" {
+			g.states[a.Positive] = true
+		}
+	}
+
+	return nil
+}
+
+// IsPressed ...
+func (g *GamepadPad) IsPressed(b domain.ButtonType) bool {
+	return g.states[b]
+}
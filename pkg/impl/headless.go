@@ -0,0 +1,63 @@
+package impl
+
+import (
+	"nes-go/pkg/domain"
+	"sync"
+)
+
+// HeadlessRenderer ... ウィンドウを開かずにフレームを数えるRenderer
+// 指定したフレーム数を描画したらRunを終了する（テストやトレースの採取用）
+type HeadlessRenderer struct {
+	mu       sync.Mutex
+	frames   int // 描画したフレーム数
+	maxFrame int // 0の場合はCloseされるまで終了しない
+
+	closed chan struct{}
+	once   sync.Once
+}
+
+// NewHeadlessRenderer ...
+func NewHeadlessRenderer(maxFrame int) *HeadlessRenderer {
+	return &HeadlessRenderer{
+		maxFrame: maxFrame,
+		closed:   make(chan struct{}),
+	}
+}
+
+// Run ... Closeされるか、指定したフレーム数を描画するまで待つ
+func (h *HeadlessRenderer) Run() error {
+	<-h.closed
+	return nil
+}
+
+// Render ...
+func (h *HeadlessRenderer) Render(s *domain.Screen) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.frames++
+	if h.maxFrame > 0 && h.frames >= h.maxFrame {
+		h.Close()
+	}
+	return nil
+}
+
+// SetFrameRate ... 表示しないので何もしない
+func (h *HeadlessRenderer) SetFrameRate(fps float64) {}
+
+// AddUpdateHook ... 入力を受け付けないので呼び出さない
+func (h *HeadlessRenderer) AddUpdateHook(f func() error) {}
+
+// Close ...
+func (h *HeadlessRenderer) Close() {
+	h.once.Do(func() {
+		close(h.closed)
+	})
+}
+
+// GetFrameCount ... 描画したフレーム数
+func (h *HeadlessRenderer) GetFrameCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.frames
+}
@@ -0,0 +1,202 @@
+package impl
+
+import (
+	"nes-go/pkg/domain"
+	"nes-go/pkg/log"
+
+	"github.com/hajimehoshi/ebiten"
+	"github.com/hajimehoshi/ebiten/inpututil"
+)
+
+var slotKeys = []ebiten.Key{
+	ebiten.Key0,
+	ebiten.Key1,
+	ebiten.Key2,
+	ebiten.Key3,
+	ebiten.Key4,
+	ebiten.Key5,
+	ebiten.Key6,
+	ebiten.Key7,
+	ebiten.Key8,
+	ebiten.Key9,
+}
+
+const (
+	saveStateKey = ebiten.KeyF5
+	loadStateKey = ebiten.KeyF7
+	rewindKey    = ebiten.KeyBackspace
+
+	pauseKey        = ebiten.KeyP
+	advanceFrameKey = ebiten.KeyPeriod
+	slowerKey       = ebiten.KeyMinus
+	fasterKey       = ebiten.KeyEqual
+	unthrottleKey   = ebiten.KeyTab
+
+	resetKey      = ebiten.KeyF9
+	powerCycleKey = ebiten.KeyF10
+)
+
+// StateSlotHotkeys ... セーブステートのホットキー
+// 0-9: スロットを選択, F5: 選択中のスロットに保存, F7: 選択中のスロットから読み込み
+type StateSlotHotkeys struct {
+	nes  *domain.NES
+	slot int
+}
+
+// NewStateSlotHotkeys ...
+func NewStateSlotHotkeys(nes *domain.NES) *StateSlotHotkeys {
+	return &StateSlotHotkeys{
+		nes:  nes,
+		slot: 0,
+	}
+}
+
+// Update ... Renderer.AddUpdateHookに登録して使う
+// 保存・読み込みに失敗してもエミュレーションは継続する
+func (h *StateSlotHotkeys) Update() error {
+	for i, k := range slotKeys {
+		if inpututil.IsKeyJustPressed(k) {
+			h.slot = i
+			log.Info("state slot: %v", h.slot)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(saveStateKey) {
+		if err := h.nes.SaveStateSlot(h.slot); err != nil {
+			log.Warn("failed to save state[slot=%v]: %+v", h.slot, err)
+		} else {
+			log.Info("state saved: %v", h.nes.GetStateSlotPath(h.slot))
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(loadStateKey) {
+		if err := h.nes.LoadStateSlot(h.slot); err != nil {
+			log.Warn("failed to load state[slot=%v]: %+v", h.slot, err)
+		} else {
+			log.Info("state loaded: %v", h.nes.GetStateSlotPath(h.slot))
+		}
+	}
+
+	return nil
+}
+
+// RewindHotkey ... 巻き戻しのホットキー
+// Backspace: 押している間、1フレームずつ巻き戻す
+type RewindHotkey struct {
+	nes       *domain.NES
+	rewinding bool
+}
+
+// NewRewindHotkey ...
+func NewRewindHotkey(nes *domain.NES) *RewindHotkey {
+	return &RewindHotkey{
+		nes:       nes,
+		rewinding: false,
+	}
+}
+
+// Update ... Renderer.AddUpdateHookに登録して使う
+func (h *RewindHotkey) Update() error {
+	pressed := ebiten.IsKeyPressed(rewindKey)
+	if pressed != h.rewinding {
+		h.rewinding = pressed
+		h.nes.SetRewinding(pressed)
+	}
+	return nil
+}
+
+// SpeedHotkeys ... 一時停止・コマ送り・速度変更のホットキー
+// P: 一時停止/再開, .: コマ送り, -: 遅くする, =: 速くする, Tab: 押している間は速度制限なし
+type SpeedHotkeys struct {
+	nes        *domain.NES
+	speedIdx   int // domain.SpeedStepsの番号
+	unthrottle bool
+}
+
+// NewSpeedHotkeys ...
+func NewSpeedHotkeys(nes *domain.NES) *SpeedHotkeys {
+	idx := 0
+	for i, s := range domain.SpeedSteps {
+		if s == 1 {
+			idx = i
+		}
+	}
+	return &SpeedHotkeys{
+		nes:      nes,
+		speedIdx: idx,
+	}
+}
+
+// Update ... Renderer.AddUpdateHookに登録して使う
+func (h *SpeedHotkeys) Update() error {
+	if inpututil.IsKeyJustPressed(pauseKey) {
+		if h.nes.IsPaused() {
+			h.nes.Resume()
+			log.Info("resumed")
+		} else {
+			h.nes.Pause()
+			log.Info("paused")
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(advanceFrameKey) {
+		h.nes.AdvanceFrame()
+	}
+
+	changed := false
+	if inpututil.IsKeyJustPressed(slowerKey) && h.speedIdx > 0 {
+		h.speedIdx--
+		changed = true
+	}
+	if inpututil.IsKeyJustPressed(fasterKey) && h.speedIdx < len(domain.SpeedSteps)-1 {
+		h.speedIdx++
+		changed = true
+	}
+	if pressed := ebiten.IsKeyPressed(unthrottleKey); pressed != h.unthrottle {
+		h.unthrottle = pressed
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	speed := domain.SpeedSteps[h.speedIdx]
+	if h.unthrottle {
+		speed = domain.SpeedUnthrottled
+	}
+	if err := h.nes.SetSpeed(speed); err != nil {
+		log.Warn("failed to set speed: %+v", err)
+		return nil
+	}
+	log.Info("speed: %v", speed)
+	return nil
+}
+
+// ResetHotkeys ... リセット・電源の入れ直しのホットキー
+// F9: リセット, F10: 電源を入れ直す
+type ResetHotkeys struct {
+	nes *domain.NES
+}
+
+// NewResetHotkeys ...
+func NewResetHotkeys(nes *domain.NES) *ResetHotkeys {
+	return &ResetHotkeys{
+		nes: nes,
+	}
+}
+
+// Update ... Renderer.AddUpdateHookに登録して使う
+func (h *ResetHotkeys) Update() error {
+	if inpututil.IsKeyJustPressed(resetKey) {
+		h.nes.Reset()
+		log.Info("reset")
+	}
+
+	if inpututil.IsKeyJustPressed(powerCycleKey) {
+		h.nes.PowerCycle()
+		log.Info("power cycled")
+	}
+
+	return nil
+}
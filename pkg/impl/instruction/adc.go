@@ -46,27 +46,14 @@ func (c *ADC) Execute(op []byte) (cycle int, err error) {
 			return
 		}
 
-		b, err = c.bus.ReadByCPU(addr)
+		b, err = c.memory.Read(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
 		}
 	}
 	c.recorder.Data = &b
-	ans := uint16(c.registers.A) + uint16(b)
-	if c.registers.P.Carry {
-		ans = ans + 1
-	}
 
-	beforeA := c.registers.A
-	c.registers.A = byte(ans & 0xFF)
-	c.registers.P.UpdateN(c.registers.A)
-	if (b & 0x80) == 0x00 {
-		c.registers.P.UpdateV(beforeA, c.registers.A)
-	} else {
-		c.registers.P.ClearV()
-	}
-	c.registers.P.UpdateZ(c.registers.A)
-	c.registers.P.UpdateC(ans)
+	c.addWithCarry(b)
 	return
 }
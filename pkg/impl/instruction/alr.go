@@ -0,0 +1,49 @@
+package instruction
+
+import (
+	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
+)
+
+// ALR ... AND + LSR
+type ALR struct {
+	BaseInstruction
+}
+
+// Execute ...
+func (c *ALR) Execute(op []byte) (cycle int, err error) {
+	mne := c.ocp.Mnemonic
+	mode := c.ocp.AddressingMode
+	cycle = c.ocp.Cycle
+
+	log.Trace("begin[%#x][%v][%v][%#v] ...", c.registers.PC, mne, mode, op)
+
+	c.recorder.Mnemonic = mne
+	c.recorder.Documented = c.ocp.Documented
+	c.recorder.AddressingMode = mode
+
+	defer func() {
+		if err != nil {
+			log.Warn("end[%v][%v][%#v] => %v", mne, mode, op, err)
+		} else {
+			log.Trace("end[%v][%v][%#v] => completed", mne, mode, op)
+		}
+	}()
+
+	if len(op) < 1 {
+		err = xerrors.Errorf("data is nil; mnemonic: %#v, op: %#v", mne, op)
+		return
+	}
+	b := op[0]
+	c.recorder.Data = &b
+
+	ans := c.registers.A & b
+	c.registers.P.Carry = (ans & 0x01) == 0x01
+	ans = ans >> 1
+	c.registers.UpdateA(ans)
+
+	c.registers.P.UpdateN(ans)
+	c.registers.P.UpdateZ(ans)
+	return
+}
@@ -0,0 +1,73 @@
+package instruction
+
+import "nes-go/pkg/domain"
+
+// isDecimalEnabled ... BCD演算が有効か（2A03はDフラグを無視する）
+func (b *BaseInstruction) isDecimalEnabled() bool {
+	return b.variant == domain.CPUVariantNMOS6502 && b.registers.P.DecimalMode
+}
+
+// addWithCarry ... Aにデータとキャリーを加算（ADC,RRAで使用）
+func (b *BaseInstruction) addWithCarry(data byte) {
+	a := b.registers.A
+	var carry uint16
+	if b.registers.P.Carry {
+		carry = 1
+	}
+
+	ans := uint16(a) + uint16(data) + carry
+	b.registers.P.UpdateZ(byte(ans))
+
+	if !b.isDecimalEnabled() {
+		b.registers.UpdateA(byte(ans))
+		b.registers.P.UpdateN(byte(ans))
+		b.registers.P.Overflow = (^(a ^ data) & (a ^ byte(ans)) & 0x80) == 0x80
+		b.registers.P.UpdateC(ans)
+		return
+	}
+
+	// BCD演算（NMOS 6502ではN,Vは上位桁の補正前の値から決まる）
+	lo := uint16(a&0x0F) + uint16(data&0x0F) + carry
+	if lo >= 0x0A {
+		lo = ((lo + 0x06) & 0x0F) + 0x10
+	}
+	dec := uint16(a&0xF0) + uint16(data&0xF0) + lo
+	b.registers.P.UpdateN(byte(dec))
+	b.registers.P.Overflow = (^(a ^ data) & (a ^ byte(dec)) & 0x80) == 0x80
+	if dec >= 0xA0 {
+		dec = dec + 0x60
+	}
+	b.registers.UpdateA(byte(dec))
+	b.registers.P.UpdateC(dec)
+}
+
+// subtractWithCarry ... Aからデータと借り(キャリーの反転)を減算（SBC,ISCで使用）
+func (b *BaseInstruction) subtractWithCarry(data byte) {
+	a := b.registers.A
+	var borrow uint16
+	if !b.registers.P.Carry {
+		borrow = 1
+	}
+
+	ans := uint16(a) - uint16(data) - borrow
+	b.registers.P.UpdateN(byte(ans))
+	b.registers.P.UpdateZ(byte(ans))
+	b.registers.P.Overflow = ((a ^ data) & (a ^ byte(ans)) & 0x80) == 0x80
+	b.registers.P.Carry = (ans & 0xFF00) == 0x0000
+
+	if !b.isDecimalEnabled() {
+		b.registers.UpdateA(byte(ans))
+		return
+	}
+
+	// BCD演算（NMOS 6502ではフラグは2進数の演算結果から決まる）
+	lo := int(a&0x0F) - int(data&0x0F) - int(borrow)
+	if lo < 0 {
+		lo = ((lo - 0x06) & 0x0F) - 0x10
+	}
+	dec := int(a&0xF0) - int(data&0xF0) + lo
+	if dec < 0 {
+		dec = dec - 0x60
+	}
+	b.registers.UpdateA(byte(dec))
+}
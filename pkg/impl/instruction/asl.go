@@ -46,7 +46,7 @@ func (c *ASL) Execute(op []byte) (cycle int, err error) {
 			return
 		}
 
-		b, err = c.bus.ReadByCPU(addr)
+		b, err = c.memory.Read(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -54,7 +54,7 @@ func (c *ASL) Execute(op []byte) (cycle int, err error) {
 		c.recorder.Data = &b
 
 		ans = b << 1
-		err = c.bus.WriteByCPU(addr, ans)
+		err = c.memory.Write(addr, ans)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -0,0 +1,49 @@
+package instruction
+
+import (
+	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
+)
+
+// AXS ... (A AND X) - 即値 をXに格納（ボローなしの減算、フラグはCMPと同様）
+type AXS struct {
+	BaseInstruction
+}
+
+// Execute ...
+func (c *AXS) Execute(op []byte) (cycle int, err error) {
+	mne := c.ocp.Mnemonic
+	mode := c.ocp.AddressingMode
+	cycle = c.ocp.Cycle
+
+	log.Trace("begin[%#x][%v][%v][%#v] ...", c.registers.PC, mne, mode, op)
+
+	c.recorder.Mnemonic = mne
+	c.recorder.Documented = c.ocp.Documented
+	c.recorder.AddressingMode = mode
+
+	defer func() {
+		if err != nil {
+			log.Warn("end[%v][%v][%#v] => %v", mne, mode, op, err)
+		} else {
+			log.Trace("end[%v][%v][%#v] => completed", mne, mode, op)
+		}
+	}()
+
+	if len(op) < 1 {
+		err = xerrors.Errorf("data is nil; mnemonic: %#v, op: %#v", mne, op)
+		return
+	}
+	b := op[0]
+	c.recorder.Data = &b
+
+	ax := c.registers.A & c.registers.X
+	ans := ax - b
+	c.registers.UpdateX(ans)
+
+	c.registers.P.Carry = ax >= b
+	c.registers.P.UpdateN(ans)
+	c.registers.P.UpdateZ(ans)
+	return
+}
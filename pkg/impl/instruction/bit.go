@@ -39,7 +39,7 @@ func (c *BIT) Execute(op []byte) (cycle int, err error) {
 	}
 
 	var b byte
-	b, err = c.bus.ReadByCPU(addr)
+	b, err = c.memory.Read(addr)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
@@ -1,7 +1,10 @@
 package instruction
 
 import (
+	"nes-go/pkg/domain"
 	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
 )
 
 // BRK ...
@@ -29,7 +32,35 @@ func (c *BRK) Execute(op []byte) (cycle int, err error) {
 		}
 	}()
 
-	c.registers.P.BreakMode = true
+	// BRKの次の1バイトは読み飛ばされる
 	c.registers.IncrementPC()
+
+	if err = c.pushStack(byte((c.registers.PC & 0xFF00) >> 8)); err != nil {
+		err = xerrors.Errorf(": %w", err)
+		return
+	}
+	if err = c.pushStack(byte(c.registers.PC & 0x00FF)); err != nil {
+		err = xerrors.Errorf(": %w", err)
+		return
+	}
+
+	// スタックに格納するフラグはBフラグがセットされた状態になる
+	p := c.registers.P.ToByte() | 0x30
+	if err = c.pushStack(p); err != nil {
+		err = xerrors.Errorf(": %w", err)
+		return
+	}
+	c.registers.P.UpdateI(true)
+
+	var l, h byte
+	if l, err = c.memory.Read(domain.Address(0xFFFE)); err != nil {
+		err = xerrors.Errorf(": %w", err)
+		return
+	}
+	if h, err = c.memory.Read(domain.Address(0xFFFF)); err != nil {
+		err = xerrors.Errorf(": %w", err)
+		return
+	}
+	c.registers.UpdatePC((uint16(h) << 8) | uint16(l))
 	return
 }
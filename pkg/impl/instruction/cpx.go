@@ -46,7 +46,7 @@ func (c *CPX) Execute(op []byte) (cycle int, err error) {
 			return
 		}
 
-		b, err = c.bus.ReadByCPU(addr)
+		b, err = c.memory.Read(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
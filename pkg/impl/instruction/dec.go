@@ -39,7 +39,7 @@ func (c *DEC) Execute(op []byte) (cycle int, err error) {
 	}
 
 	var b byte
-	b, err = c.bus.ReadByRecorder(addr)
+	b, err = c.peek(addr)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
@@ -47,7 +47,7 @@ func (c *DEC) Execute(op []byte) (cycle int, err error) {
 	c.recorder.Data = &b
 
 	ans := b - 1
-	err = c.bus.WriteByCPU(addr, ans)
+	err = c.memory.Write(addr, ans)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
@@ -8,6 +8,9 @@ import (
 	"golang.org/x/xerrors"
 )
 
+// unstableMagicConstant ... XAA・LAX(即値)の結果に影響する不安定な定数（実機でよく見られる値）
+const unstableMagicConstant = 0xEE
+
 // Instruction ...
 type Instruction interface {
 	SetAllParams(org *BaseInstruction)
@@ -18,25 +21,31 @@ type Instruction interface {
 // Factory ...
 type Factory struct {
 	Registers *component.CPURegisters
-	Bus       domain.Bus
+	Memory    domain.Memory
+	Variant   domain.CPUVariant
 	Recorder  *domain.Recorder
+	Peek      func(domain.Address) (byte, error) // 副作用なしでメモリを読み込む（記録用）
 	Fetch     func() (byte, error)
 	PushStack func(byte) error
 	PopStack  func() (byte, error)
+	Jam       func()
 }
 
 // Make ...
 func (f *Factory) Make(ocp *domain.OpcodeProp) Instruction {
 	params := BaseInstruction{
 		registers: f.Registers,
-		bus:       f.Bus,
+		memory:    f.Memory,
+		variant:   f.Variant,
 		recorder:  f.Recorder,
 
 		ocp: ocp,
 
+		peek:      f.Peek,
 		fetch:     f.Fetch,
 		pushStack: f.PushStack,
 		popStack:  f.PopStack,
+		jam:       f.Jam,
 	}
 
 	var ins Instruction
@@ -169,6 +178,28 @@ func (f *Factory) Make(ocp *domain.OpcodeProp) Instruction {
 		ins = &SRE{}
 	case domain.RRA:
 		ins = &RRA{}
+	case domain.ANC:
+		ins = &ANC{}
+	case domain.ALR:
+		ins = &ALR{}
+	case domain.ARR:
+		ins = &ARR{}
+	case domain.AXS:
+		ins = &AXS{}
+	case domain.XAA:
+		ins = &XAA{}
+	case domain.LAS:
+		ins = &LAS{}
+	case domain.AHX:
+		ins = &AHX{}
+	case domain.SHX:
+		ins = &SHX{}
+	case domain.SHY:
+		ins = &SHY{}
+	case domain.TAS:
+		ins = &TAS{}
+	case domain.STP:
+		ins = &STP{}
 	case domain.NOP:
 		ins = &NOP{}
 	default:
@@ -181,25 +212,31 @@ func (f *Factory) Make(ocp *domain.OpcodeProp) Instruction {
 // BaseInstruction ...
 type BaseInstruction struct {
 	registers *component.CPURegisters
-	bus       domain.Bus
+	memory    domain.Memory
+	variant   domain.CPUVariant
 
 	ocp      *domain.OpcodeProp
 	recorder *domain.Recorder
 
+	peek      func(domain.Address) (byte, error)
 	fetch     func() (byte, error)
 	pushStack func(byte) error
 	popStack  func() (byte, error)
+	jam       func()
 }
 
 // SetAllParams ...
 func (b *BaseInstruction) SetAllParams(org *BaseInstruction) {
 	b.registers = org.registers
-	b.bus = org.bus
+	b.memory = org.memory
+	b.variant = org.variant
 	b.ocp = org.ocp
 	b.recorder = org.recorder
+	b.peek = org.peek
 	b.fetch = org.fetch
 	b.pushStack = org.pushStack
 	b.popStack = org.popStack
+	b.jam = org.jam
 }
 
 // FetchAsOperand ...
@@ -331,14 +368,14 @@ func (b *BaseInstruction) makeAddress(op []byte) (addr domain.Address, pageCross
 		b.recorder.AddAddress(destL)
 
 		var l byte
-		l, err = b.bus.ReadByCPU(destL)
+		l, err = b.memory.Read(destL)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
 		}
 
 		var h byte
-		h, err = b.bus.ReadByCPU(destH)
+		h, err = b.memory.Read(destH)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -356,14 +393,14 @@ func (b *BaseInstruction) makeAddress(op []byte) (addr domain.Address, pageCross
 		destH := domain.Address(d + 1)
 
 		var h byte
-		h, err = b.bus.ReadByCPU(destH)
+		h, err = b.memory.Read(destH)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
 		}
 
 		var l byte
-		l, err = b.bus.ReadByCPU(destL)
+		l, err = b.memory.Read(destL)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -390,14 +427,14 @@ func (b *BaseInstruction) makeAddress(op []byte) (addr domain.Address, pageCross
 		b.recorder.AddAddress(destL)
 
 		var addrL byte
-		addrL, err = b.bus.ReadByCPU(destL)
+		addrL, err = b.memory.Read(destL)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
 		}
 
 		var addrH byte
-		addrH, err = b.bus.ReadByCPU(destH)
+		addrH, err = b.memory.Read(destH)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -412,6 +449,43 @@ func (b *BaseInstruction) makeAddress(op []byte) (addr domain.Address, pageCross
 	}
 }
 
+// storeUnstable ... AHX,SHX,SHY,TASの書き込み
+// 書き込む値は (インデックス加算前のアドレスの上位バイト+1) とのANDとなり、
+// ページをまたいだ場合は書き込み先アドレスの上位バイトが書き込む値に置き換わる
+func (b *BaseInstruction) storeUnstable(op []byte, value byte) error {
+	addr, pageCrossed, err := b.makeAddress(op)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	var index byte
+	switch b.ocp.AddressingMode {
+	case domain.IndexedAbsoluteX:
+		index = b.registers.X
+	case domain.IndexedAbsoluteY, domain.IndirectIndexed:
+		index = b.registers.Y
+	default:
+		return xerrors.Errorf("failed to store, AddressingMode is not supported; mode: %#v", b.ocp.AddressingMode)
+	}
+
+	base := uint16(addr) - uint16(index)
+	ans := value & (byte(base>>8) + 1)
+	if pageCrossed {
+		addr = domain.Address((uint16(ans) << 8) | (uint16(addr) & 0x00FF))
+	}
+
+	data, err := b.peek(addr)
+	if err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	b.recorder.Data = &data
+
+	if err := b.memory.Write(addr, ans); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
+}
+
 // Execute ...
 func (c *BaseInstruction) Execute(op []byte) (cycle int, err error) {
 	return 0, xerrors.Errorf("failed to exec, mnemonic is not supported; mnemonic: %#v", c.ocp.AddressingMode)
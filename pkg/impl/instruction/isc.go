@@ -38,7 +38,7 @@ func (c *ISC) Execute(op []byte) (cycle int, err error) {
 	}
 
 	var b byte
-	b, err = c.bus.ReadByCPU(addr)
+	b, err = c.memory.Read(addr)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
@@ -46,26 +46,12 @@ func (c *ISC) Execute(op []byte) (cycle int, err error) {
 	c.recorder.Data = &b
 
 	ans1 := b + 1
-	err = c.bus.WriteByCPU(addr, ans1)
+	err = c.memory.Write(addr, ans1)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
 	}
 
-	ans2 := uint16(c.registers.A) - uint16(ans1)
-	if !c.registers.P.Carry {
-		ans2 = ans2 - 1
-	}
-
-	beforeA := c.registers.A
-	c.registers.A = byte(ans2 & 0x00FF)
-	c.registers.P.UpdateN(c.registers.A)
-	if (beforeA & 0x80) == (b & 0x80) {
-		c.registers.P.ClearV()
-	} else {
-		c.registers.P.UpdateV(beforeA, c.registers.A)
-	}
-	c.registers.P.UpdateZ(c.registers.A)
-	c.registers.P.Carry = (ans2 & 0xFF00) == 0x0000
+	c.subtractWithCarry(ans1)
 	return
 }
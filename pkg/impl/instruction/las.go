@@ -0,0 +1,59 @@
+package instruction
+
+import (
+	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
+)
+
+// LAS ... メモリの値 AND S をA,X,Sに格納
+type LAS struct {
+	BaseInstruction
+}
+
+// Execute ...
+func (c *LAS) Execute(op []byte) (cycle int, err error) {
+	mne := c.ocp.Mnemonic
+	mode := c.ocp.AddressingMode
+	cycle = c.ocp.Cycle
+
+	log.Trace("begin[%#x][%v][%v][%#v] ...", c.registers.PC, mne, mode, op)
+
+	c.recorder.Mnemonic = mne
+	c.recorder.Documented = c.ocp.Documented
+	c.recorder.AddressingMode = mode
+
+	defer func() {
+		if err != nil {
+			log.Warn("end[%v][%v][%#v] => %v", mne, mode, op, err)
+		} else {
+			log.Trace("end[%v][%v][%#v] => completed", mne, mode, op)
+		}
+	}()
+
+	addr, pageCrossed, err := c.makeAddress(op)
+	if err != nil {
+		err = xerrors.Errorf(": %w", err)
+		return
+	}
+
+	b, err := c.memory.Read(addr)
+	if err != nil {
+		err = xerrors.Errorf(": %w", err)
+		return
+	}
+	c.recorder.Data = &b
+
+	if pageCrossed {
+		cycle++
+	}
+
+	ans := b & c.registers.S
+	c.registers.UpdateA(ans)
+	c.registers.UpdateX(ans)
+	c.registers.UpdateS(ans)
+
+	c.registers.P.UpdateN(ans)
+	c.registers.P.UpdateZ(ans)
+	return
+}
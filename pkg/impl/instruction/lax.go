@@ -38,7 +38,10 @@ func (c *LAX) Execute(op []byte) (cycle int, err error) {
 			err = xerrors.Errorf("data is nil; mnemonic: %#v, op: %#v", mne, op)
 			return
 		}
-		b = op[0]
+		c.recorder.Data = &op[0]
+
+		// 即値の場合は不安定な命令（LXA）となる
+		b = (c.registers.A | unstableMagicConstant) & op[0]
 	} else {
 		var addr domain.Address
 		var pageCrossed bool
@@ -47,7 +50,7 @@ func (c *LAX) Execute(op []byte) (cycle int, err error) {
 			return
 		}
 
-		b, err = c.bus.ReadByCPU(addr)
+		b, err = c.memory.Read(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -56,8 +59,8 @@ func (c *LAX) Execute(op []byte) (cycle int, err error) {
 		if c.ocp.AddressingMode == domain.IndirectIndexed && pageCrossed {
 			cycle++
 		}
+		c.recorder.Data = &b
 	}
-	c.recorder.Data = &b
 
 	c.registers.UpdateA(b)
 	c.registers.UpdateX(b)
@@ -60,7 +60,7 @@ func (c *NOP) Execute(op []byte) (cycle int, err error) {
 		}
 
 		var b byte
-		b, err = c.bus.ReadByRecorder(addr)
+		b, err = c.peek(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -49,7 +49,7 @@ func (c *ROL) Execute(op []byte) (cycle int, err error) {
 			return
 		}
 
-		b, err = c.bus.ReadByCPU(addr)
+		b, err = c.memory.Read(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -61,7 +61,7 @@ func (c *ROL) Execute(op []byte) (cycle int, err error) {
 			ans = ans + 1
 		}
 
-		err = c.bus.WriteByCPU(addr, ans)
+		err = c.memory.Write(addr, ans)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -50,7 +50,7 @@ func (c *ROR) Execute(op []byte) (cycle int, err error) {
 			return
 		}
 
-		b, err = c.bus.ReadByCPU(addr)
+		b, err = c.memory.Read(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -62,7 +62,7 @@ func (c *ROR) Execute(op []byte) (cycle int, err error) {
 			ans = ans | 0x80
 		}
 
-		err = c.bus.WriteByCPU(addr, ans)
+		err = c.memory.Write(addr, ans)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -50,7 +50,7 @@ func (c *RRA) Execute(op []byte) (cycle int, err error) {
 			return
 		}
 
-		b, err = c.bus.ReadByCPU(addr)
+		b, err = c.memory.Read(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -62,7 +62,7 @@ func (c *RRA) Execute(op []byte) (cycle int, err error) {
 			ans1 = ans1 | 0x80
 		}
 
-		err = c.bus.WriteByCPU(addr, ans1)
+		err = c.memory.Write(addr, ans1)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -70,20 +70,6 @@ func (c *RRA) Execute(op []byte) (cycle int, err error) {
 	}
 	c.registers.P.Carry = (b & 0x01) == 0x01
 
-	ans2 := uint16(c.registers.A) + uint16(ans1)
-	if c.registers.P.Carry {
-		ans2 = ans2 + 1
-	}
-
-	beforeA := c.registers.A
-	c.registers.A = byte(ans2 & 0xFF)
-	c.registers.P.UpdateN(c.registers.A)
-	if (b & 0x80) == 0x00 {
-		c.registers.P.UpdateV(beforeA, c.registers.A)
-	} else {
-		c.registers.P.ClearV()
-	}
-	c.registers.P.UpdateZ(c.registers.A)
-	c.registers.P.UpdateC(ans2)
+	c.addWithCarry(ans1)
 	return
 }
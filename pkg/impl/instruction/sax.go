@@ -60,7 +60,7 @@ func (c *SAX) Execute(op []byte) (cycle int, err error) {
 		}
 
 		var b byte
-		b, err = c.bus.ReadByRecorder(addr)
+		b, err = c.peek(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -75,7 +75,7 @@ func (c *SAX) Execute(op []byte) (cycle int, err error) {
 		}
 
 		ans := c.registers.A & c.registers.X
-		err = c.bus.WriteByCPU(addr, ans)
+		err = c.memory.Write(addr, ans)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
@@ -46,27 +46,14 @@ func (c *SBC) Execute(op []byte) (cycle int, err error) {
 			return
 		}
 
-		b, err = c.bus.ReadByCPU(addr)
+		b, err = c.memory.Read(addr)
 		if err != nil {
 			err = xerrors.Errorf(": %w", err)
 			return
 		}
 	}
 	c.recorder.Data = &b
-	ans := uint16(c.registers.A) - uint16(b)
-	if !c.registers.P.Carry {
-		ans = ans - 1
-	}
 
-	beforeA := c.registers.A
-	c.registers.A = byte(ans & 0x00FF)
-	c.registers.P.UpdateN(c.registers.A)
-	if (beforeA & 0x80) == (b & 0x80) {
-		c.registers.P.ClearV()
-	} else {
-		c.registers.P.UpdateV(beforeA, c.registers.A)
-	}
-	c.registers.P.UpdateZ(c.registers.A)
-	c.registers.P.Carry = (ans & 0xFF00) == 0x0000
+	c.subtractWithCarry(b)
 	return
 }
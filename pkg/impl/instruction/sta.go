@@ -39,14 +39,14 @@ func (c *STA) Execute(op []byte) (cycle int, err error) {
 	}
 
 	var b byte
-	b, err = c.bus.ReadByRecorder(addr)
+	b, err = c.peek(addr)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
 	}
 	c.recorder.Data = &b
 
-	err = c.bus.WriteByCPU(addr, c.registers.A)
+	err = c.memory.Write(addr, c.registers.A)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
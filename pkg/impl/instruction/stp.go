@@ -0,0 +1,28 @@
+package instruction
+
+import (
+	"nes-go/pkg/log"
+)
+
+// STP ... CPUを停止させる（リセットされるまで命令を実行しない）
+type STP struct {
+	BaseInstruction
+}
+
+// Execute ...
+func (c *STP) Execute(op []byte) (cycle int, err error) {
+	mne := c.ocp.Mnemonic
+	mode := c.ocp.AddressingMode
+	cycle = c.ocp.Cycle
+
+	log.Trace("begin[%#x][%v][%v][%#v] ...", c.registers.PC, mne, mode, op)
+
+	c.recorder.Mnemonic = mne
+	c.recorder.Documented = c.ocp.Documented
+	c.recorder.AddressingMode = mode
+
+	defer log.Trace("end[%v][%v][%#v] => jammed", mne, mode, op)
+
+	c.jam()
+	return
+}
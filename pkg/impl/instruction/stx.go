@@ -39,14 +39,14 @@ func (c *STX) Execute(op []byte) (cycle int, err error) {
 	}
 
 	var b byte
-	b, err = c.bus.ReadByRecorder(addr)
+	b, err = c.peek(addr)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
 	}
 	c.recorder.Data = &b
 
-	err = c.bus.WriteByCPU(addr, c.registers.X)
+	err = c.memory.Write(addr, c.registers.X)
 	if err != nil {
 		err = xerrors.Errorf(": %w", err)
 		return
@@ -0,0 +1,41 @@
+package instruction
+
+import (
+	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
+)
+
+// TAS ... A AND X をSに格納し、S AND (アドレス上位バイト+1) を書き込む
+type TAS struct {
+	BaseInstruction
+}
+
+// Execute ...
+func (c *TAS) Execute(op []byte) (cycle int, err error) {
+	mne := c.ocp.Mnemonic
+	mode := c.ocp.AddressingMode
+	cycle = c.ocp.Cycle
+
+	log.Trace("begin[%#x][%v][%v][%#v] ...", c.registers.PC, mne, mode, op)
+
+	c.recorder.Mnemonic = mne
+	c.recorder.Documented = c.ocp.Documented
+	c.recorder.AddressingMode = mode
+
+	defer func() {
+		if err != nil {
+			log.Warn("end[%v][%v][%#v] => %v", mne, mode, op, err)
+		} else {
+			log.Trace("end[%v][%v][%#v] => completed", mne, mode, op)
+		}
+	}()
+
+	c.registers.UpdateS(c.registers.A & c.registers.X)
+
+	if err = c.storeUnstable(op, c.registers.S); err != nil {
+		err = xerrors.Errorf(": %w", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,47 @@
+package instruction
+
+import (
+	"nes-go/pkg/log"
+
+	"golang.org/x/xerrors"
+)
+
+// XAA ... TXA + AND（不安定な命令のため、実機でよく見られる定数0xEEを用いる）
+type XAA struct {
+	BaseInstruction
+}
+
+// Execute ...
+func (c *XAA) Execute(op []byte) (cycle int, err error) {
+	mne := c.ocp.Mnemonic
+	mode := c.ocp.AddressingMode
+	cycle = c.ocp.Cycle
+
+	log.Trace("begin[%#x][%v][%v][%#v] ...", c.registers.PC, mne, mode, op)
+
+	c.recorder.Mnemonic = mne
+	c.recorder.Documented = c.ocp.Documented
+	c.recorder.AddressingMode = mode
+
+	defer func() {
+		if err != nil {
+			log.Warn("end[%v][%v][%#v] => %v", mne, mode, op, err)
+		} else {
+			log.Trace("end[%v][%v][%#v] => completed", mne, mode, op)
+		}
+	}()
+
+	if len(op) < 1 {
+		err = xerrors.Errorf("data is nil; mnemonic: %#v, op: %#v", mne, op)
+		return
+	}
+	b := op[0]
+	c.recorder.Data = &b
+
+	ans := (c.registers.A | unstableMagicConstant) & c.registers.X & b
+	c.registers.UpdateA(ans)
+
+	c.registers.P.UpdateN(ans)
+	c.registers.P.UpdateZ(ans)
+	return
+}
@@ -0,0 +1,144 @@
+package impl
+
+import (
+	"fmt"
+	"nes-go/pkg/domain"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten"
+	"golang.org/x/xerrors"
+)
+
+// KeyMapping ... ボタンごとのキー割り当て（どれか1つでも押されていればボタンを押したことにする）
+type KeyMapping map[domain.ButtonType][]ebiten.Key
+
+// defaultKeyNames ... プレイヤーごとのデフォルトのキー割り当て（3P・4Pはゲームパッドのみ）
+var defaultKeyNames = [4]map[domain.ButtonType][]string{
+	{
+		domain.ButtonTypeA:      {"A"},
+		domain.ButtonTypeB:      {"B"},
+		domain.ButtonTypeSelect: {"Shift"},
+		domain.ButtonTypeStart:  {"Space", "Enter"},
+		domain.ButtonTypeUp:     {"Up"},
+		domain.ButtonTypeDown:   {"Down"},
+		domain.ButtonTypeLeft:   {"Left"},
+		domain.ButtonTypeRight:  {"Right"},
+		domain.ButtonTypeTurboA: {"S"},
+		domain.ButtonTypeTurboB: {"V"},
+	},
+	{
+		domain.ButtonTypeA:      {"H"},
+		domain.ButtonTypeB:      {"G"},
+		domain.ButtonTypeSelect: {"T"},
+		domain.ButtonTypeStart:  {"Y"},
+		domain.ButtonTypeUp:     {"I"},
+		domain.ButtonTypeDown:   {"K"},
+		domain.ButtonTypeLeft:   {"J"},
+		domain.ButtonTypeRight:  {"L"},
+		domain.ButtonTypeTurboA: {"N"},
+		domain.ButtonTypeTurboB: {"M"},
+	},
+	{},
+	{},
+}
+
+// DefaultKeyNames ... プレイヤー（0: 1P, 1: 2P, 2: 3P, 3: 4P）のデフォルトのキー割り当て（ボタン名: キー名）
+func DefaultKeyNames(player int) map[string][]string {
+	names := map[string][]string{}
+	for b, keys := range defaultKeyNames[player] {
+		names[string(b)] = append([]string{}, keys...)
+	}
+	return names
+}
+
+// ParseKey ... キーの名前（ebiten.Key.Stringの値、大文字小文字は区別しない）からキーを取得
+func ParseKey(name string) (ebiten.Key, error) {
+	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+		if strings.EqualFold(k.String(), name) {
+			return k, nil
+		}
+	}
+	return 0, xerrors.Errorf("unknown key: %v", name)
+}
+
+// ParseKeyMapping ... ボタン名とキー名の割り当てからKeyMappingを作る
+func ParseKeyMapping(names map[string][]string) (KeyMapping, error) {
+	m := KeyMapping{}
+	for button, keys := range names {
+		b, err := domain.ParseButtonType(button)
+		if err != nil {
+			return nil, xerrors.Errorf(": %w", err)
+		}
+		if _, ok := m[b]; ok {
+			return nil, xerrors.Errorf("button %v is specified more than once", b)
+		}
+		for _, name := range keys {
+			k, err := ParseKey(name)
+			if err != nil {
+				return nil, xerrors.Errorf("button %v: %w", b, err)
+			}
+			m[b] = append(m[b], k)
+		}
+	}
+	return m, nil
+}
+
+// hotkeys ... ホットキーとして使っているキー
+func hotkeys() map[ebiten.Key]string {
+	keys := map[ebiten.Key]string{
+		saveStateKey:    "save state",
+		loadStateKey:    "load state",
+		rewindKey:       "rewind",
+		pauseKey:        "pause",
+		advanceFrameKey: "advance frame",
+		slowerKey:       "slower",
+		fasterKey:       "faster",
+		unthrottleKey:   "unthrottle",
+		resetKey:        "reset",
+		powerCycleKey:   "power cycle",
+	}
+	for _, k := range slotKeys {
+		keys[k] = "state slot"
+	}
+	return keys
+}
+
+// keyOwners ... キーの割り当て先（1つのキーを複数の操作に割り当てていないかの確認用）
+type keyOwners map[ebiten.Key]string
+
+// newKeyOwners ... ホットキーを割り当て済みのkeyOwners
+func newKeyOwners() keyOwners {
+	o := keyOwners{}
+	for k, name := range hotkeys() {
+		o[k] = "hotkey " + name
+	}
+	return o
+}
+
+// add ...
+func (o keyOwners) add(k ebiten.Key, owner string) error {
+	if prev, ok := o[k]; ok {
+		return xerrors.Errorf("key %v is assigned to both %v and %v", k, prev, owner)
+	}
+	o[k] = owner
+	return nil
+}
+
+// addMappings ... エラーメッセージが毎回同じになるように、プレイヤー・ボタンの定義順に追加する
+func (o keyOwners) addMappings(mappings []KeyMapping) error {
+	for i, m := range mappings {
+		for _, b := range domain.ButtonList {
+			for _, k := range m[b] {
+				if err := o.add(k, fmt.Sprintf("pad%v %v", i+1, b)); err != nil {
+					return xerrors.Errorf(": %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateKeyMappings ... 1つのキーが複数のボタン（他のプレイヤーを含む）やホットキーに割り当てられていないか確認する
+func ValidateKeyMappings(mappings ...KeyMapping) error {
+	return newKeyOwners().addMappings(mappings)
+}
@@ -0,0 +1,157 @@
+package impl_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl"
+
+	"github.com/hajimehoshi/ebiten"
+)
+
+func TestParseKeyMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   map[string][]string
+		want    impl.KeyMapping
+		wantErr string // エラーメッセージに含まれる文字列（空の場合はエラーにならない）
+	}{
+		{
+			name: "when multiple keys are assigned, all of them are used",
+			names: map[string][]string{
+				"A":     {"X"},
+				"START": {"Enter", "Space"},
+			},
+			want: impl.KeyMapping{
+				domain.ButtonTypeA:     {ebiten.KeyX},
+				domain.ButtonTypeStart: {ebiten.KeyEnter, ebiten.KeySpace},
+			},
+		},
+		{
+			name: "when names are lower case, they are parsed",
+			names: map[string][]string{
+				"select": {"shift"},
+			},
+			want: impl.KeyMapping{
+				domain.ButtonTypeSelect: {ebiten.KeyShift},
+			},
+		},
+		{
+			name: "when key name is unknown, return error",
+			names: map[string][]string{
+				"A": {"X", "NoSuchKey"},
+			},
+			wantErr: "unknown key: NoSuchKey",
+		},
+		{
+			name: "when button name is unknown, return error",
+			names: map[string][]string{
+				"C": {"X"},
+			},
+			wantErr: "unknown button: C",
+		},
+		{
+			name: "when button is specified in different cases, return error",
+			names: map[string][]string{
+				"A": {"X"},
+				"a": {"Z"},
+			},
+			wantErr: "is specified more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := impl.ParseKeyMapping(tt.names)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("wrong error\nwant: %#v\ngot: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateKeyMappings(t *testing.T) {
+	defaults := func(players int) []map[string][]string {
+		names := []map[string][]string{}
+		for i := 0; i < players; i++ {
+			names = append(names, impl.DefaultKeyNames(i))
+		}
+		return names
+	}
+
+	tests := []struct {
+		name    string
+		names   []map[string][]string // プレイヤーごとの割り当て
+		wantErr string                // エラーメッセージに含まれる文字列（空の場合はエラーにならない）
+	}{
+		{
+			name:  "when default mappings of all players are used, no key conflicts",
+			names: defaults(4),
+		},
+		{
+			name: "when a key is assigned to two buttons of a player, return error",
+			names: []map[string][]string{
+				{"A": {"X"}, "B": {"Z", "X"}},
+			},
+			wantErr: "key X is assigned to both pad1 A and pad1 B",
+		},
+		{
+			name: "when a key is assigned to buttons of two players, return error",
+			names: []map[string][]string{
+				impl.DefaultKeyNames(0),
+				{"A": {"H"}, "B": {"A"}},
+			},
+			wantErr: "key A is assigned to both pad1 A and pad2 B",
+		},
+		{
+			name: "when a key is assigned to a button and a hotkey, return error",
+			names: []map[string][]string{
+				{"START": {"P"}},
+			},
+			wantErr: "key P is assigned to both hotkey pause and pad1 START",
+		},
+		{
+			name: "when a key is assigned to a button and a state slot hotkey, return error",
+			names: []map[string][]string{
+				impl.DefaultKeyNames(0),
+				{"SELECT": {"1"}},
+			},
+			wantErr: "key 1 is assigned to both hotkey state slot and pad2 SELECT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mappings := []impl.KeyMapping{}
+			for _, names := range tt.names {
+				m, err := impl.ParseKeyMapping(names)
+				if err != nil {
+					t.Fatalf("failed to parse: %v", err)
+				}
+				mappings = append(mappings, m)
+			}
+
+			err := impl.ValidateKeyMappings(mappings...)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("wrong error\nwant: %#v\ngot: %v", nil, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("wrong error\nwant: %#v\ngot: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -9,12 +9,12 @@ import (
 
 // Pad ...
 type Pad struct {
-	mapping map[domain.ButtonType]ebiten.Key
+	mapping KeyMapping
 	states  map[domain.ButtonType]bool
 }
 
 // NewPad ...
-func NewPad(mapping map[domain.ButtonType]ebiten.Key) domain.Pad {
+func NewPad(mapping KeyMapping) domain.Pad {
 	return &Pad{
 		mapping: mapping,
 		states:  map[domain.ButtonType]bool{},
@@ -50,12 +50,11 @@ func (p *Pad) IsPressed(key domain.ButtonType) (pressed bool) {
 
 // isPressed ...
 func (p *Pad) isPressed(nesKey domain.ButtonType) (pressed bool) {
-	pcKey, ok := p.mapping[nesKey]
-	if !ok {
-		pressed = false
-	} else {
-		pressed = ebiten.IsKeyPressed(pcKey)
+	for _, pcKey := range p.mapping[nesKey] {
+		if ebiten.IsKeyPressed(pcKey) {
+			return true
+		}
 	}
 
-	return
+	return false
 }
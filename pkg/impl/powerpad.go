@@ -0,0 +1,101 @@
+package impl
+
+import (
+	"fmt"
+	"nes-go/pkg/domain"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten"
+	"golang.org/x/xerrors"
+)
+
+// PowerPadMapping ... Power Padのボタン（使う面の番号）ごとのキー割り当て
+type PowerPadMapping map[int][]ebiten.Key
+
+// defaultPowerPadKeyNames ... 面ごとのデフォルトのキー割り当て（テンキーをマットの配置に見立てる）
+//
+//	7 8 9 -    B面:  1  2  3  4    A面:  . 1 2 .
+//	4 5 6 +          5  6  7  8          3 4 5 6
+//	1 2 3 Enter      9 10 11 12          . 7 8 .
+var defaultPowerPadKeyNames = map[domain.PowerPadSide][]string{
+	domain.PowerPadSideA: {"KP8", "KP9", "KP4", "KP5", "KP6", "KPAdd", "KP2", "KP3"},
+	domain.PowerPadSideB: {"KP7", "KP8", "KP9", "KPSubtract", "KP4", "KP5", "KP6", "KPAdd", "KP1", "KP2", "KP3", "KPEnter"},
+}
+
+// DefaultPowerPadKeyNames ... 面のデフォルトのキー割り当て（ボタンの番号: キー名）
+func DefaultPowerPadKeyNames(side domain.PowerPadSide) map[string][]string {
+	names := map[string][]string{}
+	for i, k := range defaultPowerPadKeyNames[side] {
+		names[strconv.Itoa(i+1)] = []string{k}
+	}
+	return names
+}
+
+// ParsePowerPadMapping ... ボタンの番号とキー名の割り当てからPowerPadMappingを作る
+func ParsePowerPadMapping(side domain.PowerPadSide, names map[string][]string) (PowerPadMapping, error) {
+	m := PowerPadMapping{}
+	for button, keys := range names {
+		b, err := strconv.Atoi(button)
+		if err != nil || b < 1 || b > side.GetButtonNum() {
+			return nil, xerrors.Errorf("side %v has buttons 1-%v: %v", side, side.GetButtonNum(), button)
+		}
+		for _, name := range keys {
+			k, err := ParseKey(name)
+			if err != nil {
+				return nil, xerrors.Errorf("power pad button %v: %w", b, err)
+			}
+			m[b] = append(m[b], k)
+		}
+	}
+	return m, nil
+}
+
+// ValidatePowerPadMapping ... Power Padのキーがパッドのボタンやホットキーと重複していないか確認する
+func ValidatePowerPadMapping(powerPad PowerPadMapping, mappings ...KeyMapping) error {
+	o := newKeyOwners()
+	if err := o.addMappings(mappings); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	// エラーメッセージが毎回同じになるように、ボタンの番号順に確認する
+	for b := 1; b <= domain.PowerPadSideB.GetButtonNum(); b++ {
+		for _, k := range powerPad[b] {
+			if err := o.add(k, fmt.Sprintf("power pad %v", b)); err != nil {
+				return xerrors.Errorf(": %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// KeyboardPowerPadInput ... キーボードで操作するPower Pad
+type KeyboardPowerPadInput struct {
+	mapping PowerPadMapping
+	pressed map[int]bool
+}
+
+// NewKeyboardPowerPadInput ...
+func NewKeyboardPowerPadInput(mapping PowerPadMapping) *KeyboardPowerPadInput {
+	return &KeyboardPowerPadInput{
+		mapping: mapping,
+		pressed: map[int]bool{},
+	}
+}
+
+// Load ...
+func (k *KeyboardPowerPadInput) Load() error {
+	for b, keys := range k.mapping {
+		k.pressed[b] = false
+		for _, key := range keys {
+			if ebiten.IsKeyPressed(key) {
+				k.pressed[b] = true
+			}
+		}
+	}
+	return nil
+}
+
+// IsButtonPressed ...
+func (k *KeyboardPowerPadInput) IsButtonPressed(button int) bool {
+	return k.pressed[button]
+}
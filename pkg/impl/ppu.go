@@ -3,6 +3,7 @@ package impl
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"nes-go/pkg/domain"
 	"nes-go/pkg/impl/component"
 	"nes-go/pkg/log"
@@ -66,6 +67,44 @@ func (p *PPU) SetRecorder(r *domain.Recorder) {
 	p.recorder = r
 }
 
+// SetRegion ... NTSCのみ対応のため何もしない
+func (p *PPU) SetRegion(r domain.Region) {
+}
+
+// PowerOn ...
+func (p *PPU) PowerOn() {
+	p.registers = component.NewPPURegisters()
+	p.drawingPoint = &image.Point{0, 0}
+	p.oam = component.NewPPUOAM()
+	p.enableOAMDMA = false
+}
+
+// Reset ...
+func (p *PPU) Reset() {
+	status := p.registers.PPUStatus
+	p.registers = component.NewPPURegisters()
+	p.registers.PPUStatus = status
+}
+
+// MarshalState ... セーブステートには対応していない
+func (p *PPU) MarshalState() ([]byte, error) {
+	return nil, xerrors.Errorf("save state is not supported by PPU; use PPU2")
+}
+
+// UnmarshalState ... セーブステートには対応していない
+func (p *PPU) UnmarshalState(data []byte) error {
+	return xerrors.Errorf("save state is not supported by PPU; use PPU2")
+}
+
+// SetWarmUp ... ウォームアップには対応していないため何もしない
+func (p *PPU) SetWarmUp(w bool) {
+}
+
+// GetOutputPixel ... 描画途中の画素は扱わない
+func (p *PPU) GetOutputPixel(x, y int) (color.RGBA, int, bool) {
+	return color.RGBA{}, 0, false
+}
+
 // incrementPPUADDR
 func (p *PPU) incrementPPUADDR() {
 	if p.registers.PPUCtrl.VRAMAddressIncrementMode == 0 {
@@ -19,23 +19,31 @@ type PPU2 struct {
 	bgController *component.BackgroundController
 	spController *component.SpriteController
 
-	images [][]color.RGBA
+	images  [2][][]color.RGBA // ダブルバッファ（返したScreenは次のScreenを返すまで書き換えない）
+	drawing int               // 描画中のバッファの番号
 
 	dot      uint16
 	scanline uint16
 
-	enableOAMDMA bool
-
 	rendered bool
 
+	timing domain.RegionTiming
+
+	oddFrame       bool // 奇数フレームか
+	suppressVBlank bool // VBlankフラグのセットを抑制するか
+	warmingUp      bool // 電源投入直後でPPUCTRL/PPUMASK/PPUSCROLL/PPUADDRへの書き込みを無視するか
+
 	recorder *domain.Recorder
 }
 
 // NewPPU2 ...
 func NewPPU2() domain.PPU {
-	images := make([][]color.RGBA, domain.ResolutionHeight)
-	for i := range images {
-		images[i] = make([]color.RGBA, domain.ResolutionWidth)
+	images := [2][][]color.RGBA{}
+	for b := range images {
+		images[b] = make([][]color.RGBA, domain.ResolutionHeight)
+		for i := range images[b] {
+			images[b][i] = make([]color.RGBA, domain.ResolutionWidth)
+		}
 	}
 
 	return &PPU2{
@@ -44,10 +52,14 @@ func NewPPU2() domain.PPU {
 		bgController:      component.NewBackgroundController(),
 		spController:      component.NewSpriteController(),
 		images:            images,
+		drawing:           0,
 		dot:               0,
 		scanline:          0,
-		enableOAMDMA:      false,
 		rendered:          false,
+		timing:            domain.RegionNTSC.GetTiming(),
+		oddFrame:          false,
+		suppressVBlank:    false,
+		warmingUp:         false,
 		recorder:          &domain.Recorder{},
 	}
 }
@@ -72,6 +84,122 @@ func (p *PPU2) SetRecorder(r *domain.Recorder) {
 	p.recorder = r
 }
 
+// SetRegion ...
+func (p *PPU2) SetRegion(r domain.Region) {
+	p.timing = r.GetTiming()
+}
+
+// PowerOn ... 電源投入時の状態にする（OAMも含めて初期化する）
+func (p *PPU2) PowerOn() {
+	p.registers = component.NewPPURegisters()
+	p.internalRegisters = component.NewPPUInnerRegisters()
+	p.bgController = component.NewBackgroundController()
+	p.spController = component.NewSpriteController()
+	if p.bus != nil {
+		p.bgController.SetBus(p.bus)
+		p.spController.SetBus(p.bus)
+	}
+	p.dot = 0
+	p.scanline = 0
+	p.rendered = false
+	p.oddFrame = false
+	p.suppressVBlank = false
+}
+
+// Reset ... RESET信号によりレジスタを初期化する（PPUSTATUSとOAMは保持する）
+// 仕様: https://wiki.nesdev.com/w/index.php/PPU_power_up_state
+func (p *PPU2) Reset() {
+	status := p.registers.PPUStatus
+	oamAddr := p.registers.OAMAddr
+	p.registers = component.NewPPURegisters()
+	p.registers.PPUStatus = status
+	p.registers.OAMAddr = oamAddr
+	p.internalRegisters = component.NewPPUInnerRegisters()
+	p.oddFrame = false
+	p.updateNMI()
+}
+
+// SetWarmUp ... trueの間はPPUCTRL/PPUMASK/PPUSCROLL/PPUADDRへの書き込みを無視する
+func (p *PPU2) SetWarmUp(w bool) {
+	p.warmingUp = w
+}
+
+// GetOutputPixel ... 現在のフレームで出力済の画素と、出力してから経過したdot数
+// post-render line以降はRunで返したScreenの画素を返す
+func (p *PPU2) GetOutputPixel(x, y int) (color.RGBA, int, bool) {
+	if x < 0 || x >= domain.ResolutionWidth || y < 0 || y >= domain.ResolutionHeight {
+		return color.RGBA{}, 0, false
+	}
+
+	// (x, y)の画素はスキャンラインyのdot x+1で出力する
+	dotsAgo := (int(p.scanline)-y)*341 + int(p.dot) - (x + 1)
+	if dotsAgo <= 0 {
+		return color.RGBA{}, 0, false
+	}
+
+	image := p.images[p.drawing]
+	if p.scanline >= domain.ResolutionHeight {
+		// post-render lineで描画中のバッファを入れ替えている
+		image = p.images[1-p.drawing]
+	}
+	return image[y][x], dotsAgo, true
+}
+
+// ppu2State ... セーブステート用の状態（描画途中の画像は保存しない）
+type ppu2State struct {
+	Registers         component.PPURegistersState
+	InternalRegisters component.PPUInternalRegistersState
+	Background        component.BackgroundControllerState
+	Sprite            component.SpriteControllerState
+
+	Dot            uint16
+	Scanline       uint16
+	Rendered       bool
+	OddFrame       bool
+	SuppressVBlank bool
+	WarmingUp      bool
+}
+
+// MarshalState ...
+func (p *PPU2) MarshalState() ([]byte, error) {
+	data, err := domain.EncodeState(ppu2State{
+		Registers:         p.registers.GetState(),
+		InternalRegisters: p.internalRegisters.GetState(),
+		Background:        p.bgController.GetState(),
+		Sprite:            p.spController.GetState(),
+		Dot:               p.dot,
+		Scanline:          p.scanline,
+		Rendered:          p.rendered,
+		OddFrame:          p.oddFrame,
+		SuppressVBlank:    p.suppressVBlank,
+		WarmingUp:         p.warmingUp,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf(": %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalState ...
+func (p *PPU2) UnmarshalState(data []byte) error {
+	s := ppu2State{}
+	if err := domain.DecodeState(data, &s); err != nil {
+		return xerrors.Errorf(": %w", err)
+	}
+
+	p.registers.SetState(s.Registers)
+	p.internalRegisters.SetState(s.InternalRegisters)
+	p.bgController.SetState(s.Background)
+	p.spController.SetState(s.Sprite)
+	p.dot = s.Dot
+	p.scanline = s.Scanline
+	p.rendered = s.Rendered
+	p.oddFrame = s.OddFrame
+	p.suppressVBlank = s.SuppressVBlank
+	p.warmingUp = s.WarmingUp
+	return nil
+}
+
 // incrementPPUADDR
 func (p *PPU2) incrementPPUADDR() {
 	if p.registers.PPUCtrl.VRAMAddressIncrementMode == 0 {
@@ -110,6 +238,10 @@ func (p *PPU2) ReadRegisters(addr domain.Address) (byte, error) {
 		data = p.registers.PPUMask.ToByte()
 	case 2:
 		target = "PPUSTATUS"
+		if p.scanline == p.timing.VBlankScanline && p.dot == 1 {
+			// VBlankフラグがセットされる直前に読み込むと、そのフレームではフラグもNMIも発生しない
+			p.suppressVBlank = true
+		}
 		data = p.registers.PPUStatus.ToByte()
 		p.internalRegisters.ClearW()
 		p.registers.PPUStatus.VBlankHasStarted = false
@@ -155,23 +287,25 @@ func (p *PPU2) WriteRegisters(addr domain.Address, data byte) error {
 		}
 	}()
 
-	if addr < 0x2000 && addr > 0x3FFF && addr != 0x4014 {
+	if addr < 0x2000 && addr > 0x3FFF {
 		target = "-"
 		err = xerrors.Errorf("address is out of range; addr: %#v", addr)
 		return err
 	}
 
-	if addr == 0x4014 {
-		target = "OAMDMA"
-		p.registers.OAMDMA = data
-		p.enableOAMDMA = true
-		return err
+	if p.warmingUp {
+		switch addr % 8 {
+		case 0, 1, 5, 6:
+			target = "(ignored while warming up)"
+			return err
+		}
 	}
 
 	switch addr % 8 {
 	case 0:
 		p.registers.PPUCtrl.UpdateAll(data)
 		p.internalRegisters.UpdateByPPUCtrl(data)
+		p.updateNMI()
 		target = "PPUCTRL"
 	case 1:
 		p.registers.PPUMask.UpdateAll(data)
@@ -208,24 +342,6 @@ func (p *PPU2) WriteRegisters(addr domain.Address, data byte) error {
 	return err
 }
 
-func (p *PPU2) execOAMDMA() error {
-	p.enableOAMDMA = false
-	readAddrH := uint16(p.registers.OAMDMA) << 8
-	for readAddrL := 0; readAddrL <= 0xFF; readAddrL++ {
-		readAddr := domain.Address(readAddrH + uint16(readAddrL))
-
-		// CPUのメモリマップにおけるアドレスからデータを読み込む
-		readData, err := p.bus.ReadByCPU(readAddr)
-		if err != nil {
-			err = xerrors.Errorf(": %w", err)
-			return err
-		}
-
-		p.spController.WriteOAM(uint8(readAddrL), readData)
-	}
-	return nil
-}
-
 // shift ... 各シフトレジスタのデータをシフト
 func (p *PPU2) shift() {
 	shouldSkip := true
@@ -292,37 +408,75 @@ func (p *PPU2) updatePixel() {
 		return
 	}
 
-	var bgPixel, spPixel color.RGBA
+	var bgColor, spColor uint8
+	var bgOpaque, spOpaque bool
 	var spAttr byte
 
-	if p.registers.PPUMask.EnableBackground {
-		bgPixel = p.bgController.MakePixel(p.internalRegisters.GetFineX())
+	// 左端8ピクセルはPPUMASKの設定により非表示にできる
+	showLeftBackground := x >= 8 || p.registers.PPUMask.DisableBackgroundMask
+	showLeftSprite := x >= 8 || p.registers.PPUMask.DisableSpriteMask
+
+	if p.registers.PPUMask.EnableBackground && showLeftBackground {
+		bgColor, bgOpaque = p.bgController.MakePixel(p.internalRegisters.GetFineX())
 	}
-	if p.registers.PPUMask.EnableSprite {
-		spPixel, spAttr = p.spController.MakePixel()
+	if p.registers.PPUMask.EnableSprite && showLeftSprite {
+		spColor, spAttr, spOpaque = p.spController.MakePixel()
 	}
 
-	if bgPixel.A == 0 && spPixel.A == 0 {
-		p.images[y][x] = color.RGBA{R: 0, G: 0, B: 0, A: 0xFF}
-		return
-	}
-	if bgPixel.A == 0 && spPixel.A != 0 {
-		p.images[y][x] = spPixel
-		return
+	var colorIndex uint8
+	switch {
+	case !bgOpaque && !spOpaque:
+		colorIndex = p.getBackdropColorIndex()
+	case !bgOpaque && spOpaque:
+		colorIndex = spColor
+	case bgOpaque && !spOpaque:
+		colorIndex = bgColor
+	case (spAttr & 0x20) == 0x20:
+		// 背景の後ろに表示するスプライト
+		colorIndex = bgColor
+	default:
+		colorIndex = spColor
 	}
-	if bgPixel.A != 0 && spPixel.A == 0 {
-		p.images[y][x] = bgPixel
-		return
+
+	if p.registers.PPUMask.IsGrayscale() {
+		colorIndex = colorIndex & 0x30
 	}
-	if (spAttr & 0x08) == 0x08 {
-		p.images[y][x] = bgPixel
-		return
+
+	emphasis := p.registers.PPUMask.GetEmphasis()
+	if p.timing.SwapEmphasisRG {
+		// PAL・Dendyでは赤と緑の強調ビットが入れ替わっている
+		emphasis = (emphasis & domain.EmphasizeBlue) |
+			((emphasis & domain.EmphasizeRed) << 1) |
+			((emphasis & domain.EmphasizeGreen) >> 1)
 	}
-	p.images[y][x] = spPixel
+
+	r, g, b := domain.GetSystemColor(colorIndex, emphasis)
+	p.images[p.drawing][y][x] = color.RGBA{R: r, G: g, B: b, A: 0xFF}
 
 	//log.Trace("PPU[%v,%v]update pixel completed (x,y)=(%v,%v), (r,g,b)=(%v,%v,%v)", p.dot, p.scanline, x, y, pixel.R, pixel.G, pixel.B)
 }
 
+// isRenderingEnabled ... 背景とスプライトのどちらかの描画が有効か
+func (p *PPU2) isRenderingEnabled() bool {
+	return p.registers.PPUMask.EnableBackground || p.registers.PPUMask.EnableSprite
+}
+
+// getBackdropColorIndex ... 背景色の色番号
+// 描画無効時にvがパレット領域(0x3F00-0x3FFF)を指している場合はそのパレットの色、それ以外は0x3F00の色
+func (p *PPU2) getBackdropColorIndex() uint8 {
+	addr := p.internalRegisters.GetVRAMAddress()
+	if p.isRenderingEnabled() || addr < 0x3F00 {
+		return p.bus.GetPalette(0).GetColorIndex(0)
+	}
+
+	offset := uint8(addr & 0x1F)
+	if (offset & 0x13) == 0x10 {
+		// 0x3F10,0x3F14,0x3F18,0x3F1Cは0x3F00,0x3F04,0x3F08,0x3F0Cのミラー
+		offset = offset & 0x0F
+	}
+	return p.bus.GetPalette(offset >> 2).GetColorIndex(offset & 0x03)
+}
+
 func (p *PPU2) incrementHorizontal() error {
 	p.internalRegisters.IncrementHorizontal()
 	return nil
@@ -401,10 +555,32 @@ func (p *PPU2) fetchHighBGTileByte() error {
 
 // setVBlankFlag ...
 func (p *PPU2) setVBlankFlag() error {
+	if p.suppressVBlank {
+		p.suppressVBlank = false
+		return nil
+	}
 	p.registers.PPUStatus.VBlankHasStarted = true
 	return nil
 }
 
+// updateNMI ... NMIの信号線を更新
+// VBlankフラグがセットされた直後(dot 1-2)にPPUSTATUSが読まれるとNMIは発生しないため、信号はdot 3から出力する
+func (p *PPU2) updateNMI() {
+	active := p.registers.PPUCtrl.NMIEnable && p.registers.PPUStatus.VBlankHasStarted
+	if p.scanline == p.timing.VBlankScanline && p.dot < 3 {
+		active = false
+	}
+	p.bus.SendNMI(active)
+}
+
+// shouldSkipLastDot ... 奇数フレームかつ描画有効時はpre-render lineの最終dotをスキップ（NTSCのみ）
+func (p *PPU2) shouldSkipLastDot() bool {
+	if !p.timing.SkipOddFrameDot {
+		return false
+	}
+	return p.scanline == p.timing.GetPreRenderScanline() && p.dot == 339 && p.oddFrame && p.isRenderingEnabled()
+}
+
 // clearFlags ...
 func (p *PPU2) clearFlags() error {
 	p.registers.PPUStatus.VBlankHasStarted = false
@@ -422,7 +598,7 @@ func (p *PPU2) clearSecondaryOAM() error {
 
 // evaluateSprite ...
 func (p *PPU2) evaluateSprite() error {
-	p.spController.EvaluateSprite(p.scanline)
+	p.spController.EvaluateSprite(p.scanline, p.registers.PPUCtrl.GetSpriteHeight())
 	return nil
 }
 
@@ -432,14 +608,21 @@ func (p *PPU2) fetchSprite() error {
 		return nil
 	}
 
-	p.spController.FetchSprite(p.scanline, p.registers.PPUCtrl.SpritePatternTableIndex)
+	p.spController.FetchSprite(
+		p.scanline,
+		p.registers.PPUCtrl.SpritePatternTableIndex,
+		p.registers.PPUCtrl.GetSpriteHeight(),
+	)
 	return nil
 }
 
 // updateSpriteController ...
 func (p *PPU2) updateSpriteController() error {
+	if !p.isRenderingEnabled() {
+		return nil
+	}
 
-	if p.scanline >= 240 && p.scanline <= 260 {
+	if p.scanline >= 240 && p.scanline < p.timing.GetPreRenderScanline() {
 		return nil
 	}
 
@@ -474,12 +657,12 @@ func (p *PPU2) run1Cycle() error {
 	log.Trace("PPU[%v,%v] run start", p.dot, p.scanline)
 	defer log.Trace("PPU[%v,%v] run end: internal register : %#v", p.dot, p.scanline, *p.internalRegisters)
 
-	p.shift()
-	p.setNextData()
+	if p.isRenderingEnabled() {
+		p.shift()
+		p.setNextData()
+	}
 	p.updatePixel()
 
-	p.bus.SendNMI(p.registers.PPUCtrl.NMIEnable && p.registers.PPUStatus.VBlankHasStarted)
-
 	// 次の仕様にしたがって更新
 	// http://wiki.nesdev.com/w/index.php/PPU_rendering
 
@@ -489,7 +672,7 @@ func (p *PPU2) run1Cycle() error {
 
 	// Visible scanlines (0-239)
 	if p.scanline >= 0 && p.scanline <= 239 {
-		if p.dot == 0 {
+		if p.dot == 0 || !p.isRenderingEnabled() {
 			return nil
 		}
 
@@ -545,15 +728,15 @@ func (p *PPU2) run1Cycle() error {
 		}
 	}
 
-	if p.scanline == 241 && p.dot == 1 {
+	if p.scanline == p.timing.VBlankScanline && p.dot == 1 {
 		if err := p.setVBlankFlag(); err != nil {
 			return xerrors.Errorf(": %w", err)
 		}
 		return nil
 	}
 
-	// Pre-render line (261)
-	if p.scanline == 261 {
+	// Pre-render line (NTSCは261、PAL・Dendyは311)
+	if p.scanline == p.timing.GetPreRenderScanline() {
 		if p.dot == 1 {
 			if err := p.clearFlags(); err != nil {
 				return xerrors.Errorf(": %w", err)
@@ -561,6 +744,10 @@ func (p *PPU2) run1Cycle() error {
 			return nil
 		}
 
+		if !p.isRenderingEnabled() {
+			return nil
+		}
+
 		if p.dot == 257 {
 			if err := p.updateHorizontalToLeftEdge(); err != nil {
 				return xerrors.Errorf(": %w", err)
@@ -619,42 +806,39 @@ func (p *PPU2) Run(cycle int) (*domain.Screen, error) {
 	}()
 
 	for i := 0; i < cycle; i++ {
-		if p.enableOAMDMA {
-			if err := p.execOAMDMA(); err != nil {
-				return nil, xerrors.Errorf(": %w", err)
-			}
-			continue
-		}
-
 		if err := p.run1Cycle(); err != nil {
 			return nil, xerrors.Errorf(": %w", err)
 		}
+		p.updateNMI()
 
-		if p.dot < 340 {
+		if p.dot < 340 && !p.shouldSkipLastDot() {
 			p.dot++
 			continue
 		}
 
 		p.dot = 0
 
-		if p.scanline < 261 {
+		if p.scanline < p.timing.GetPreRenderScanline() {
 			p.scanline++
 			continue
 		}
 
 		p.scanline = 0
+		p.oddFrame = !p.oddFrame
 	}
 
 	// post-render line のときだけ1回返す
 	if p.scanline == 240 && !p.rendered {
 		log.Trace("PPU[%v,%v] return images", p.dot, p.scanline)
 		p.rendered = true
+		completed := p.images[p.drawing]
+		p.drawing = 1 - p.drawing
 		return &domain.Screen{
 			TileImages:            nil,
 			SpriteImages:          nil,
 			DisableSpriteMask:     false,
 			DisableBackgroundMask: false,
-			Images:                p.images,
+			Images:                completed,
 		}, nil
 	}
 	return nil, nil
@@ -0,0 +1,289 @@
+package impl_test
+
+import (
+	"image/color"
+	"testing"
+
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl"
+	"nes-go/pkg/mock_domain"
+
+	"github.com/golang/mock/gomock"
+)
+
+// setupPPU2 ... 空のCHR-ROMを接続したBusとPPU2を生成（電源投入済）
+func setupPPU2(t *testing.T, cpu domain.CPU) (domain.PPU, domain.Bus) {
+	t.Helper()
+
+	prg := domain.PRGROM(make([]byte, 0x8000))
+	chr := domain.CHRROM(make([]byte, 0x2000))
+	rom := &domain.ROM{
+		Header: &domain.INESHeader{PRGROMSize: 0x02, CHRROMSize: 0x01},
+		Prgrom: &prg,
+		Chrrom: &chr,
+	}
+
+	bus := impl.NewBus()
+	ppu := impl.NewPPU2()
+	bus.Setup(rom, ppu, cpu, domain.NewVRAM(), nil, nil)
+	ppu.SetBus(bus)
+	ppu.PowerOn()
+	return ppu, bus
+}
+
+// newMockCPU ... NMIの信号だけを受け取るCPU（nmiを指定した場合はNMIが発生したらtrueにする）
+func newMockCPU(ctrl *gomock.Controller, nmi *bool) domain.CPU {
+	cpu := mock_domain.NewMockCPU(ctrl)
+	cpu.EXPECT().ReceiveNMI(gomock.Any()).Do(func(active bool) {
+		if active && nmi != nil {
+			*nmi = true
+		}
+	}).AnyTimes()
+	return cpu
+}
+
+// writePPUADDR ... PPUADDRに2回書き込んでvを設定
+func writePPUADDR(t *testing.T, ppu domain.PPU, addr domain.Address) {
+	t.Helper()
+
+	if err := ppu.WriteRegisters(0x2006, byte(addr>>8)); err != nil {
+		t.Fatalf("failed to write PPUADDR: %v", err)
+	}
+	if err := ppu.WriteRegisters(0x2006, byte(addr)); err != nil {
+		t.Fatalf("failed to write PPUADDR: %v", err)
+	}
+}
+
+func TestPPU2Backdrop(t *testing.T) {
+	palette := map[domain.Address]byte{
+		0x3F00: 0x01,
+		0x3F04: 0x02,
+		0x3F05: 0x03,
+		0x3F1D: 0x04,
+	}
+
+	tests := []struct {
+		name      string
+		ppuMask   byte
+		v         domain.Address
+		wantIndex byte
+	}{
+		{
+			name:      "When rendering is disabled and v is not in palette, 0x3F00 is used",
+			ppuMask:   0x00,
+			v:         0x2000,
+			wantIndex: 0x01,
+		},
+		{
+			name:      "When rendering is disabled and v is in palette, the color at v is used",
+			ppuMask:   0x00,
+			v:         0x3F05,
+			wantIndex: 0x03,
+		},
+		{
+			name:      "When rendering is disabled and v is 0x3F14, its mirror 0x3F04 is used",
+			ppuMask:   0x00,
+			v:         0x3F14,
+			wantIndex: 0x02,
+		},
+		{
+			name:      "When rendering is disabled and v is in sprite palette, the sprite color is used",
+			ppuMask:   0x00,
+			v:         0x3F1D,
+			wantIndex: 0x04,
+		},
+		{
+			name:      "When rendering is disabled and v is over 0x3F1F, the mirrored color is used",
+			ppuMask:   0x00,
+			v:         0x3F25,
+			wantIndex: 0x03,
+		},
+		{
+			name:      "When background is enabled and transparent, 0x3F00 is used even if v is in palette",
+			ppuMask:   0x0A,
+			v:         0x3F05,
+			wantIndex: 0x01,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ppu, bus := setupPPU2(t, newMockCPU(ctrl, nil))
+			for addr, index := range palette {
+				if err := bus.WriteByPPU(addr, index); err != nil {
+					t.Fatalf("failed to write palette: %v", err)
+				}
+			}
+			if err := ppu.WriteRegisters(0x2001, tt.ppuMask); err != nil {
+				t.Fatalf("failed to write PPUMASK: %v", err)
+			}
+			writePPUADDR(t, ppu, tt.v)
+
+			// dot 1でscanline 0の左端の画素を出力する
+			if _, err := ppu.Run(2); err != nil {
+				t.Fatalf("failed to run: %v", err)
+			}
+
+			got, _, ok := ppu.GetOutputPixel(0, 0)
+			if !ok {
+				t.Fatalf("pixel is not output")
+			}
+			r, g, b := domain.GetSystemColor(tt.wantIndex, 0)
+			want := color.RGBA{R: r, G: g, B: b, A: 0xFF}
+			if got != want {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+			}
+		})
+	}
+}
+
+func TestPPU2OddFrameDotSkip(t *testing.T) {
+	tests := []struct {
+		name     string
+		region   domain.Region
+		ppuMask  byte
+		wantDots [2]int // 偶数フレーム、奇数フレームのdot数
+	}{
+		{
+			name:     "When rendering is enabled, the last dot of odd frames is skipped",
+			region:   domain.RegionNTSC,
+			ppuMask:  0x08,
+			wantDots: [2]int{341 * 262, 341*262 - 1},
+		},
+		{
+			name:     "When only sprites are enabled, the last dot of odd frames is skipped",
+			region:   domain.RegionNTSC,
+			ppuMask:  0x10,
+			wantDots: [2]int{341 * 262, 341*262 - 1},
+		},
+		{
+			name:     "When rendering is disabled, no dot is skipped",
+			region:   domain.RegionNTSC,
+			ppuMask:  0x00,
+			wantDots: [2]int{341 * 262, 341 * 262},
+		},
+		{
+			name:     "When region is PAL, no dot is skipped",
+			region:   domain.RegionPAL,
+			ppuMask:  0x08,
+			wantDots: [2]int{341 * 312, 341 * 312},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ppu, _ := setupPPU2(t, newMockCPU(ctrl, nil))
+			ppu.SetRegion(tt.region)
+			recorder := &domain.Recorder{}
+			ppu.SetRecorder(recorder)
+			if err := ppu.WriteRegisters(0x2001, tt.ppuMask); err != nil {
+				t.Fatalf("failed to write PPUMASK: %v", err)
+			}
+
+			// 次のフレームのscanline 0, dot 0に戻るまでのdot数を数える
+			got := [2]int{}
+			for i := range got {
+				for {
+					if _, err := ppu.Run(1); err != nil {
+						t.Fatalf("failed to run: %v", err)
+					}
+					got[i]++
+					if recorder.Scanline == 0 && recorder.Dot == 0 {
+						break
+					}
+				}
+			}
+			if got != tt.wantDots {
+				t.Errorf("wrong output\nwant: %#v\ngot: %#v", tt.wantDots, got)
+			}
+		})
+	}
+}
+
+func TestPPU2VBlankRace(t *testing.T) {
+	const vblankScanline = 241
+
+	tests := []struct {
+		name     string
+		afterDot int // VBlankのスキャンラインのこのdotまで実行してからPPUSTATUSを読む（-1の場合は前のスキャンラインの最後）
+		wantRead bool
+		wantFlag bool // 読んだ後にもう一度読んだときのVBlankフラグ
+		wantNMI  bool
+	}{
+		{
+			name:     "When PPUSTATUS is read at the end of the previous scanline, the flag is set later and NMI occurs",
+			afterDot: -1,
+			wantRead: false,
+			wantFlag: true,
+			wantNMI:  true,
+		},
+		{
+			name:     "When PPUSTATUS is read after dot 0, the flag is never set and NMI is suppressed",
+			afterDot: 0,
+			wantRead: false,
+			wantFlag: false,
+			wantNMI:  false,
+		},
+		{
+			name:     "When PPUSTATUS is read after dot 1, the flag is read and NMI is suppressed",
+			afterDot: 1,
+			wantRead: true,
+			wantFlag: false,
+			wantNMI:  false,
+		},
+		{
+			name:     "When PPUSTATUS is read after dot 2, the flag is read and NMI is suppressed",
+			afterDot: 2,
+			wantRead: true,
+			wantFlag: false,
+			wantNMI:  false,
+		},
+		{
+			name:     "When PPUSTATUS is read after dot 3, the flag is read and NMI has already occurred",
+			afterDot: 3,
+			wantRead: true,
+			wantFlag: false,
+			wantNMI:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			nmi := false
+			ppu, _ := setupPPU2(t, newMockCPU(ctrl, &nmi))
+			if err := ppu.WriteRegisters(0x2000, 0x80); err != nil {
+				t.Fatalf("failed to write PPUCTRL: %v", err)
+			}
+
+			if _, err := ppu.Run(vblankScanline*341 + tt.afterDot + 1); err != nil {
+				t.Fatalf("failed to run: %v", err)
+			}
+			status, err := ppu.ReadRegisters(0x2002)
+			if err != nil {
+				t.Fatalf("failed to read PPUSTATUS: %v", err)
+			}
+			if _, err := ppu.Run(10); err != nil {
+				t.Fatalf("failed to run: %v", err)
+			}
+			after, err := ppu.ReadRegisters(0x2002)
+			if err != nil {
+				t.Fatalf("failed to read PPUSTATUS: %v", err)
+			}
+
+			gotRead := (status & 0x80) == 0x80
+			gotFlag := (after & 0x80) == 0x80
+			if gotRead != tt.wantRead || gotFlag != tt.wantFlag || nmi != tt.wantNMI {
+				t.Errorf("wrong output\nwant: read=%#v, flag=%#v, nmi=%#v\ngot: read=%#v, flag=%#v, nmi=%#v", tt.wantRead, tt.wantFlag, tt.wantNMI, gotRead, gotFlag, nmi)
+			}
+		})
+	}
+}
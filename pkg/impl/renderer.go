@@ -2,7 +2,9 @@ package impl
 
 import (
 	"fmt"
+	"math"
 	"nes-go/pkg/domain"
+	"sync"
 	"time"
 
 	"github.com/hajimehoshi/ebiten"
@@ -18,14 +20,25 @@ type Renderer struct {
 	title    string
 	imageBuf *ebiten.Image
 
+	// 以下はebitenのgoroutineだけが使う
 	lastRenderedTime time.Time
 	fps              float64
 
 	enableDebugPrint bool
+
+	frames *domain.FrameMailbox // エミュレーションのgoroutineからのフレームの受け渡し
+
+	updateHooks []func() error
+
+	closed chan struct{} // Closeで閉じる
+	once   sync.Once
 }
 
-// NewRenderer ...
-func NewRenderer(scale float64, title string, enableDebugPrint bool) (domain.Renderer, error) {
+// errRendererClosed ... ebitenのループを終了させるためのエラー
+var errRendererClosed = xerrors.New("renderer is closed")
+
+// NewRenderer ... modeはエミュレーションが表示より速い場合にフレームを捨てるか、表示の更新を待つか
+func NewRenderer(scale float64, title string, enableDebugPrint bool, mode domain.FrameHandoffMode) (domain.Renderer, error) {
 	imageBuf, err := ebiten.NewImage(domain.ResolutionWidth, domain.ResolutionHeight, ebiten.FilterDefault)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to NewMonitor; err: %w", err)
@@ -38,11 +51,33 @@ func NewRenderer(scale float64, title string, enableDebugPrint bool) (domain.Ren
 		title:            title,
 		imageBuf:         imageBuf,
 		enableDebugPrint: enableDebugPrint,
+		frames:           domain.NewFrameMailbox(mode),
+		closed:           make(chan struct{}),
 	}, nil
 }
 
 // update ...
 func (m *Renderer) update(screen *ebiten.Image) error {
+	select {
+	case <-m.closed:
+		return errRendererClosed
+	default:
+	}
+
+	for _, h := range m.updateHooks {
+		if err := h(); err != nil {
+			return xerrors.Errorf(": %w", err)
+		}
+	}
+
+	if p, ok := m.frames.Take(); ok {
+		m.imageBuf.ReplacePixels(p)
+		m.frames.Release(p)
+
+		m.fps = 1 / time.Since(m.lastRenderedTime).Seconds()
+		m.lastRenderedTime = time.Now()
+	}
+
 	if ebiten.IsDrawingSkipped() {
 		return nil
 	}
@@ -57,23 +92,44 @@ func (m *Renderer) update(screen *ebiten.Image) error {
 
 // Run ...
 func (m *Renderer) Run() error {
-	return ebiten.Run(m.update, m.height, m.width, m.scale, m.title)
+	// 終了後にフレームの受け渡しを待っているエミュレーションを止めないようにする
+	defer m.frames.Close()
+
+	err := ebiten.Run(m.update, m.height, m.width, m.scale, m.title)
+	if err != nil && !xerrors.Is(err, errRendererClosed) {
+		return xerrors.Errorf(": %w", err)
+	}
+	return nil
 }
 
-// Render ...
-func (m *Renderer) Render(s *domain.Screen) error {
-	p := toPixels(s)
-	m.imageBuf.ReplacePixels(p)
+// Close ... ウィンドウを閉じてRunを終了させる（どのgoroutineからでも呼び出せる）
+func (m *Renderer) Close() {
+	m.once.Do(func() {
+		close(m.closed)
+	})
+}
 
-	m.fps = 1 / time.Since(m.lastRenderedTime).Seconds()
-	m.lastRenderedTime = time.Now()
+// SetFrameRate ... 画面の更新頻度を地域のフレームレートに合わせる
+func (m *Renderer) SetFrameRate(fps float64) {
+	ebiten.SetMaxTPS(int(math.Round(fps)))
+}
+
+// AddUpdateHook ... 画面の更新ごとに（UIのgoroutineで）呼び出す関数を登録
+func (m *Renderer) AddUpdateHook(h func() error) {
+	m.updateHooks = append(m.updateHooks, h)
+}
+
+// Render ... エミュレーションのgoroutineから呼び出す（sの内容はコピーしてから表示側に渡す）
+func (m *Renderer) Render(s *domain.Screen) error {
+	p := m.frames.AcquireBuffer(4 * domain.ResolutionHeight * domain.ResolutionWidth)
+	toPixels(s, p)
+	m.frames.Put(p)
 
 	return nil
 }
 
-// toPixels ...
-func toPixels(s *domain.Screen) []byte {
-	pixels := make([]byte, 4*domain.ResolutionHeight*domain.ResolutionWidth)
+// toPixels ... pixelsに書き込む
+func toPixels(s *domain.Screen, pixels []byte) []byte {
 
 	if s.Images != nil {
 		idx := 0
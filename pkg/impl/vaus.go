@@ -0,0 +1,107 @@
+package impl
+
+import (
+	"nes-go/pkg/domain"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten"
+)
+
+// MouseVausInput ... マウスで操作するVaus（つまみ: カーソルの横方向の移動量、発射: 左クリック）
+// 実機のつまみと同じく相対的に回すので、つまみの位置はカーソルの位置と一致しない
+type MouseVausInput struct {
+	position float64
+	fire     bool
+
+	lastX  int
+	loaded bool // lastXを読み込み済みか
+}
+
+// NewMouseVausInput ... つまみは中央から始める
+func NewMouseVausInput() *MouseVausInput {
+	return &MouseVausInput{
+		position: 0.5,
+	}
+}
+
+// Load ... 前回からのカーソルの横方向の移動量（画面の幅で端から端まで）だけつまみを回す
+// つまみの位置は端で止まり、それ以上回そうとした分は捨てる
+func (m *MouseVausInput) Load() error {
+	x, _ := ebiten.CursorPosition()
+	if m.loaded {
+		m.position += float64(x-m.lastX) / float64(domain.ResolutionWidth-1)
+		if m.position < 0 {
+			m.position = 0
+		}
+		if m.position > 1 {
+			m.position = 1
+		}
+	}
+	m.lastX = x
+	m.loaded = true
+
+	m.fire = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	return nil
+}
+
+// GetPosition ...
+func (m *MouseVausInput) GetPosition() float64 {
+	return m.position
+}
+
+// IsFirePressed ...
+func (m *MouseVausInput) IsFirePressed() bool {
+	return m.fire
+}
+
+// GamepadVausInput ... ゲームパッドで操作するVaus（つまみ: 軸の値、発射: ボタン）
+// GamepadPadと同じく、接続されている順番（device）でゲームパッドを指定する
+type GamepadVausInput struct {
+	device int
+	axis   int
+	button ebiten.GamepadButton
+
+	position float64
+	fire     bool
+}
+
+// NewGamepadVausInput ...
+func NewGamepadVausInput(device, axis int, button ebiten.GamepadButton) *GamepadVausInput {
+	return &GamepadVausInput{
+		device:   device,
+		axis:     axis,
+		button:   button,
+		position: 0.5,
+	}
+}
+
+// Load ... ゲームパッドが接続されていない場合はつまみを中央にする
+func (g *GamepadVausInput) Load() error {
+	g.position = 0.5
+	g.fire = false
+
+	ids := ebiten.GamepadIDs()
+	sort.Ints(ids)
+	if g.device >= len(ids) {
+		return nil
+	}
+
+	id := ids[g.device]
+	if g.axis < ebiten.GamepadAxisNum(id) {
+		g.position = (ebiten.GamepadAxis(id, g.axis) + 1) / 2
+	}
+	if int(g.button) < ebiten.GamepadButtonNum(id) {
+		g.fire = ebiten.IsGamepadButtonPressed(id, g.button)
+	}
+	return nil
+}
+
+// GetPosition ...
+func (g *GamepadVausInput) GetPosition() float64 {
+	return g.position
+}
+
+// IsFirePressed ...
+func (g *GamepadVausInput) IsFirePressed() bool {
+	return g.fire
+}
@@ -0,0 +1,37 @@
+package impl
+
+import (
+	"nes-go/pkg/domain"
+
+	"github.com/hajimehoshi/ebiten"
+)
+
+// MouseZapperInput ... マウスで操作するZapper（照準: カーソル位置、引き金: 左クリック）
+type MouseZapperInput struct {
+	x, y     int
+	onScreen bool
+	trigger  bool
+}
+
+// NewMouseZapperInput ...
+func NewMouseZapperInput() *MouseZapperInput {
+	return &MouseZapperInput{}
+}
+
+// Load ... ebitenのCursorPositionは画面の拡大率を反映済みなので、そのままNESの画素の座標になる
+func (m *MouseZapperInput) Load() error {
+	m.x, m.y = ebiten.CursorPosition()
+	m.onScreen = m.x >= 0 && m.x < domain.ResolutionWidth && m.y >= 0 && m.y < domain.ResolutionHeight
+	m.trigger = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	return nil
+}
+
+// GetAim ...
+func (m *MouseZapperInput) GetAim() (int, int, bool) {
+	return m.x, m.y, m.onScreen
+}
+
+// IsTriggerPulled ...
+func (m *MouseZapperInput) IsTriggerPulled() bool {
+	return m.trigger
+}
@@ -3,7 +3,11 @@ package log
 import (
 	"io"
 	"log"
+	"path/filepath"
 	"runtime"
+	"strings"
+
+	"golang.org/x/xerrors"
 )
 
 // Level ...
@@ -22,17 +26,36 @@ const (
 	LevelFatal
 )
 
+var levelNames = map[string]Level{
+	"trace": LevelTrace,
+	"debug": LevelDebug,
+	"info":  LevelInfo,
+	"warn":  LevelWarn,
+	"fatal": LevelFatal,
+}
+
+// ParseLevel ... trace/debug/info/warn/fatalからLevelを取得
+func ParseLevel(s string) (Level, error) {
+	l, ok := levelNames[strings.ToLower(s)]
+	if !ok {
+		return LevelInfo, xerrors.Errorf("unknown log level: %v", s)
+	}
+	return l, nil
+}
+
 // Config ...
 type Config struct {
 	logLevel         Level
 	enableLevelLabel bool
 	enableFuncName   bool
+	categories       map[string]bool // 空の場合はすべてのカテゴリを出力
 }
 
 var config = Config{
 	logLevel:         LevelDebug,
 	enableLevelLabel: true,
 	enableFuncName:   true,
+	categories:       map[string]bool{},
 }
 
 // SetOutput ...
@@ -55,6 +78,28 @@ func SetEnableFuncName(enable bool) {
 	config.enableFuncName = enable
 }
 
+// SetCategories ... Info以下のログを出力するカテゴリを絞り込む（空の場合はすべて出力）
+// カテゴリは呼び出し元のパッケージ名（例: cpu6502）もしくはパッケージ名/ファイル名（例: impl/ppu2）
+func SetCategories(categories []string) {
+	config.categories = map[string]bool{}
+	for _, c := range categories {
+		if c != "" {
+			config.categories[c] = true
+		}
+	}
+}
+
+// isCategoryEnabled ... 呼び出し元のカテゴリが出力対象か
+func isCategoryEnabled(file string) bool {
+	if len(config.categories) == 0 {
+		return true
+	}
+
+	pkg := filepath.Base(filepath.Dir(file))
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	return config.categories[pkg] || config.categories[pkg+"/"+name]
+}
+
 // SetEnableTimestamp ...
 func SetEnableTimestamp(enable bool) {
 	if enable {
@@ -69,14 +114,16 @@ func print(level string, format string, v ...interface{}) {
 	args := v
 	f := format
 
-	if config.enableFuncName {
-		pc, _, _, ok := runtime.Caller(2)
-		if ok {
-			funcName := runtime.FuncForPC(pc).Name()
+	pc, file, _, ok := runtime.Caller(2)
+	if ok && level != "W" && level != "F" && !isCategoryEnabled(file) {
+		return
+	}
 
-			args = append([]interface{}{funcName}, args...)
-			f = "[%s]" + f
-		}
+	if config.enableFuncName && ok {
+		funcName := runtime.FuncForPC(pc).Name()
+
+		args = append([]interface{}{funcName}, args...)
+		f = "[%s]" + f
 	}
 
 	if config.enableLevelLabel {
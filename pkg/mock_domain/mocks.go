@@ -6,10 +6,63 @@ package mock_domain
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	color "image/color"
 	domain "nes-go/pkg/domain"
 	reflect "reflect"
 )
 
+// MockStateSaver is a mock of StateSaver interface
+type MockStateSaver struct {
+	ctrl     *gomock.Controller
+	recorder *MockStateSaverMockRecorder
+}
+
+// MockStateSaverMockRecorder is the mock recorder for MockStateSaver
+type MockStateSaverMockRecorder struct {
+	mock *MockStateSaver
+}
+
+// NewMockStateSaver creates a new mock instance
+func NewMockStateSaver(ctrl *gomock.Controller) *MockStateSaver {
+	mock := &MockStateSaver{ctrl: ctrl}
+	mock.recorder = &MockStateSaverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStateSaver) EXPECT() *MockStateSaverMockRecorder {
+	return m.recorder
+}
+
+// MarshalState mocks base method
+func (m *MockStateSaver) MarshalState() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalState")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalState indicates an expected call of MarshalState
+func (mr *MockStateSaverMockRecorder) MarshalState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalState", reflect.TypeOf((*MockStateSaver)(nil).MarshalState))
+}
+
+// UnmarshalState mocks base method
+func (m *MockStateSaver) UnmarshalState(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarshalState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarshalState indicates an expected call of UnmarshalState
+func (mr *MockStateSaverMockRecorder) UnmarshalState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarshalState", reflect.TypeOf((*MockStateSaver)(nil).UnmarshalState), arg0)
+}
+
 // MockCPU is a mock of CPU interface
 type MockCPU struct {
 	ctrl     *gomock.Controller
@@ -33,6 +86,35 @@ func (m *MockCPU) EXPECT() *MockCPUMockRecorder {
 	return m.recorder
 }
 
+// MarshalState mocks base method
+func (m *MockCPU) MarshalState() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalState")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalState indicates an expected call of MarshalState
+func (mr *MockCPUMockRecorder) MarshalState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalState", reflect.TypeOf((*MockCPU)(nil).MarshalState))
+}
+
+// UnmarshalState mocks base method
+func (m *MockCPU) UnmarshalState(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarshalState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarshalState indicates an expected call of UnmarshalState
+func (mr *MockCPUMockRecorder) UnmarshalState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarshalState", reflect.TypeOf((*MockCPU)(nil).UnmarshalState), arg0)
+}
+
 // SetBus mocks base method
 func (m *MockCPU) SetBus(arg0 domain.Bus) {
 	m.ctrl.T.Helper()
@@ -57,6 +139,18 @@ func (mr *MockCPUMockRecorder) SetRecorder(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecorder", reflect.TypeOf((*MockCPU)(nil).SetRecorder), arg0)
 }
 
+// SetCatchUp mocks base method
+func (m *MockCPU) SetCatchUp(arg0 func(int) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCatchUp", arg0)
+}
+
+// SetCatchUp indicates an expected call of SetCatchUp
+func (mr *MockCPUMockRecorder) SetCatchUp(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCatchUp", reflect.TypeOf((*MockCPU)(nil).SetCatchUp), arg0)
+}
+
 // Run mocks base method
 func (m *MockCPU) Run() (int, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +192,96 @@ func (mr *MockCPUMockRecorder) ReceiveNMI(active interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveNMI", reflect.TypeOf((*MockCPU)(nil).ReceiveNMI), active)
 }
 
+// IsJammed mocks base method
+func (m *MockCPU) IsJammed() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsJammed")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsJammed indicates an expected call of IsJammed
+func (mr *MockCPUMockRecorder) IsJammed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsJammed", reflect.TypeOf((*MockCPU)(nil).IsJammed))
+}
+
+// PowerOn mocks base method
+func (m *MockCPU) PowerOn(arg0 domain.PowerOnProfile) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PowerOn", arg0)
+}
+
+// PowerOn indicates an expected call of PowerOn
+func (mr *MockCPUMockRecorder) PowerOn(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOn", reflect.TypeOf((*MockCPU)(nil).PowerOn), arg0)
+}
+
+// Reset mocks base method
+func (m *MockCPU) Reset() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reset")
+}
+
+// Reset indicates an expected call of Reset
+func (mr *MockCPUMockRecorder) Reset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockCPU)(nil).Reset))
+}
+
+// MockMemory is a mock of Memory interface
+type MockMemory struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemoryMockRecorder
+}
+
+// MockMemoryMockRecorder is the mock recorder for MockMemory
+type MockMemoryMockRecorder struct {
+	mock *MockMemory
+}
+
+// NewMockMemory creates a new mock instance
+func NewMockMemory(ctrl *gomock.Controller) *MockMemory {
+	mock := &MockMemory{ctrl: ctrl}
+	mock.recorder = &MockMemoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMemory) EXPECT() *MockMemoryMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method
+func (m *MockMemory) Read(arg0 domain.Address) (byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", arg0)
+	ret0, _ := ret[0].(byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read
+func (mr *MockMemoryMockRecorder) Read(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockMemory)(nil).Read), arg0)
+}
+
+// Write mocks base method
+func (m *MockMemory) Write(arg0 domain.Address, arg1 byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write
+func (mr *MockMemoryMockRecorder) Write(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockMemory)(nil).Write), arg0, arg1)
+}
+
 // MockPPU is a mock of PPU interface
 type MockPPU struct {
 	ctrl     *gomock.Controller
@@ -121,6 +305,35 @@ func (m *MockPPU) EXPECT() *MockPPUMockRecorder {
 	return m.recorder
 }
 
+// MarshalState mocks base method
+func (m *MockPPU) MarshalState() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalState")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalState indicates an expected call of MarshalState
+func (mr *MockPPUMockRecorder) MarshalState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalState", reflect.TypeOf((*MockPPU)(nil).MarshalState))
+}
+
+// UnmarshalState mocks base method
+func (m *MockPPU) UnmarshalState(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarshalState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarshalState indicates an expected call of UnmarshalState
+func (mr *MockPPUMockRecorder) UnmarshalState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarshalState", reflect.TypeOf((*MockPPU)(nil).UnmarshalState), arg0)
+}
+
 // SetBus mocks base method
 func (m *MockPPU) SetBus(arg0 domain.Bus) {
 	m.ctrl.T.Helper()
@@ -203,6 +416,70 @@ func (mr *MockPPUMockRecorder) String() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockPPU)(nil).String))
 }
 
+// SetRegion mocks base method
+func (m *MockPPU) SetRegion(arg0 domain.Region) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRegion", arg0)
+}
+
+// SetRegion indicates an expected call of SetRegion
+func (mr *MockPPUMockRecorder) SetRegion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRegion", reflect.TypeOf((*MockPPU)(nil).SetRegion), arg0)
+}
+
+// PowerOn mocks base method
+func (m *MockPPU) PowerOn() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PowerOn")
+}
+
+// PowerOn indicates an expected call of PowerOn
+func (mr *MockPPUMockRecorder) PowerOn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOn", reflect.TypeOf((*MockPPU)(nil).PowerOn))
+}
+
+// Reset mocks base method
+func (m *MockPPU) Reset() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reset")
+}
+
+// Reset indicates an expected call of Reset
+func (mr *MockPPUMockRecorder) Reset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockPPU)(nil).Reset))
+}
+
+// SetWarmUp mocks base method
+func (m *MockPPU) SetWarmUp(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetWarmUp", arg0)
+}
+
+// SetWarmUp indicates an expected call of SetWarmUp
+func (mr *MockPPUMockRecorder) SetWarmUp(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWarmUp", reflect.TypeOf((*MockPPU)(nil).SetWarmUp), arg0)
+}
+
+// GetOutputPixel mocks base method
+func (m *MockPPU) GetOutputPixel(x, y int) (color.RGBA, int, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutputPixel", x, y)
+	ret0, _ := ret[0].(color.RGBA)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
+}
+
+// GetOutputPixel indicates an expected call of GetOutputPixel
+func (mr *MockPPUMockRecorder) GetOutputPixel(x, y interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutputPixel", reflect.TypeOf((*MockPPU)(nil).GetOutputPixel), x, y)
+}
+
 // MockBus is a mock of Bus interface
 type MockBus struct {
 	ctrl     *gomock.Controller
@@ -226,8 +503,37 @@ func (m *MockBus) EXPECT() *MockBusMockRecorder {
 	return m.recorder
 }
 
+// MarshalState mocks base method
+func (m *MockBus) MarshalState() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalState")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalState indicates an expected call of MarshalState
+func (mr *MockBusMockRecorder) MarshalState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalState", reflect.TypeOf((*MockBus)(nil).MarshalState))
+}
+
+// UnmarshalState mocks base method
+func (m *MockBus) UnmarshalState(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarshalState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarshalState indicates an expected call of UnmarshalState
+func (mr *MockBusMockRecorder) UnmarshalState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarshalState", reflect.TypeOf((*MockBus)(nil).UnmarshalState), arg0)
+}
+
 // Setup mocks base method
-func (m *MockBus) Setup(arg0 *domain.ROM, arg1 domain.PPU, arg2 domain.CPU, arg3 *domain.VRAM, arg4, arg5 domain.Pad) {
+func (m *MockBus) Setup(arg0 *domain.ROM, arg1 domain.PPU, arg2 domain.CPU, arg3 *domain.VRAM, arg4, arg5 domain.PortDevice) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Setup", arg0, arg1, arg2, arg3, arg4, arg5)
 }
@@ -238,6 +544,18 @@ func (mr *MockBusMockRecorder) Setup(arg0, arg1, arg2, arg3, arg4, arg5 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Setup", reflect.TypeOf((*MockBus)(nil).Setup), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// PowerOn mocks base method
+func (m *MockBus) PowerOn(arg0 domain.PowerOnProfile) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PowerOn", arg0)
+}
+
+// PowerOn indicates an expected call of PowerOn
+func (mr *MockBusMockRecorder) PowerOn(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowerOn", reflect.TypeOf((*MockBus)(nil).PowerOn), arg0)
+}
+
 // ReadByCPU mocks base method
 func (m *MockBus) ReadByCPU(arg0 domain.Address) (byte, error) {
 	m.ctrl.T.Helper()
@@ -296,6 +614,21 @@ func (mr *MockBusMockRecorder) WriteByPPU(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteByPPU", reflect.TypeOf((*MockBus)(nil).WriteByPPU), arg0, arg1)
 }
 
+// ReadByRecorder mocks base method
+func (m *MockBus) ReadByRecorder(arg0 domain.Address) (byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByRecorder", arg0)
+	ret0, _ := ret[0].(byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByRecorder indicates an expected call of ReadByRecorder
+func (mr *MockBusMockRecorder) ReadByRecorder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByRecorder", reflect.TypeOf((*MockBus)(nil).ReadByRecorder), arg0)
+}
+
 // GetTileNo mocks base method
 func (m *MockBus) GetTileNo(arg0 uint8, arg1 domain.NameTablePoint) (uint8, error) {
 	m.ctrl.T.Helper()
@@ -381,6 +714,487 @@ func (mr *MockBusMockRecorder) SendNMI(active interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNMI", reflect.TypeOf((*MockBus)(nil).SendNMI), active)
 }
 
+// SetOAMDMA mocks base method
+func (m *MockBus) SetOAMDMA(arg0 func(byte) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOAMDMA", arg0)
+}
+
+// SetOAMDMA indicates an expected call of SetOAMDMA
+func (mr *MockBusMockRecorder) SetOAMDMA(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOAMDMA", reflect.TypeOf((*MockBus)(nil).SetOAMDMA), arg0)
+}
+
+// GetPRGRAM mocks base method
+func (m *MockBus) GetPRGRAM() []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPRGRAM")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// GetPRGRAM indicates an expected call of GetPRGRAM
+func (mr *MockBusMockRecorder) GetPRGRAM() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPRGRAM", reflect.TypeOf((*MockBus)(nil).GetPRGRAM))
+}
+
+// MockPortDevice is a mock of PortDevice interface
+type MockPortDevice struct {
+	ctrl     *gomock.Controller
+	recorder *MockPortDeviceMockRecorder
+}
+
+// MockPortDeviceMockRecorder is the mock recorder for MockPortDevice
+type MockPortDeviceMockRecorder struct {
+	mock *MockPortDevice
+}
+
+// NewMockPortDevice creates a new mock instance
+func NewMockPortDevice(ctrl *gomock.Controller) *MockPortDevice {
+	mock := &MockPortDevice{ctrl: ctrl}
+	mock.recorder = &MockPortDeviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPortDevice) EXPECT() *MockPortDeviceMockRecorder {
+	return m.recorder
+}
+
+// MarshalState mocks base method
+func (m *MockPortDevice) MarshalState() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalState")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalState indicates an expected call of MarshalState
+func (mr *MockPortDeviceMockRecorder) MarshalState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalState", reflect.TypeOf((*MockPortDevice)(nil).MarshalState))
+}
+
+// UnmarshalState mocks base method
+func (m *MockPortDevice) UnmarshalState(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarshalState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarshalState indicates an expected call of UnmarshalState
+func (mr *MockPortDeviceMockRecorder) UnmarshalState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarshalState", reflect.TypeOf((*MockPortDevice)(nil).UnmarshalState), arg0)
+}
+
+// Strobe mocks base method
+func (m *MockPortDevice) Strobe(out byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Strobe", out)
+}
+
+// Strobe indicates an expected call of Strobe
+func (mr *MockPortDeviceMockRecorder) Strobe(out interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Strobe", reflect.TypeOf((*MockPortDevice)(nil).Strobe), out)
+}
+
+// Read mocks base method
+func (m *MockPortDevice) Read() byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read")
+	ret0, _ := ret[0].(byte)
+	return ret0
+}
+
+// Read indicates an expected call of Read
+func (mr *MockPortDeviceMockRecorder) Read() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockPortDevice)(nil).Read))
+}
+
+// Peek mocks base method
+func (m *MockPortDevice) Peek() byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peek")
+	ret0, _ := ret[0].(byte)
+	return ret0
+}
+
+// Peek indicates an expected call of Peek
+func (mr *MockPortDeviceMockRecorder) Peek() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peek", reflect.TypeOf((*MockPortDevice)(nil).Peek))
+}
+
+// MockExpansionDevice is a mock of ExpansionDevice interface
+type MockExpansionDevice struct {
+	ctrl     *gomock.Controller
+	recorder *MockExpansionDeviceMockRecorder
+}
+
+// MockExpansionDeviceMockRecorder is the mock recorder for MockExpansionDevice
+type MockExpansionDeviceMockRecorder struct {
+	mock *MockExpansionDevice
+}
+
+// NewMockExpansionDevice creates a new mock instance
+func NewMockExpansionDevice(ctrl *gomock.Controller) *MockExpansionDevice {
+	mock := &MockExpansionDevice{ctrl: ctrl}
+	mock.recorder = &MockExpansionDeviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockExpansionDevice) EXPECT() *MockExpansionDeviceMockRecorder {
+	return m.recorder
+}
+
+// MarshalState mocks base method
+func (m *MockExpansionDevice) MarshalState() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalState")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalState indicates an expected call of MarshalState
+func (mr *MockExpansionDeviceMockRecorder) MarshalState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalState", reflect.TypeOf((*MockExpansionDevice)(nil).MarshalState))
+}
+
+// UnmarshalState mocks base method
+func (m *MockExpansionDevice) UnmarshalState(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarshalState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarshalState indicates an expected call of UnmarshalState
+func (mr *MockExpansionDeviceMockRecorder) UnmarshalState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarshalState", reflect.TypeOf((*MockExpansionDevice)(nil).UnmarshalState), arg0)
+}
+
+// Strobe mocks base method
+func (m *MockExpansionDevice) Strobe(out byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Strobe", out)
+}
+
+// Strobe indicates an expected call of Strobe
+func (mr *MockExpansionDeviceMockRecorder) Strobe(out interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Strobe", reflect.TypeOf((*MockExpansionDevice)(nil).Strobe), out)
+}
+
+// Read mocks base method
+func (m *MockExpansionDevice) Read(port int) byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", port)
+	ret0, _ := ret[0].(byte)
+	return ret0
+}
+
+// Read indicates an expected call of Read
+func (mr *MockExpansionDeviceMockRecorder) Read(port interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockExpansionDevice)(nil).Read), port)
+}
+
+// Peek mocks base method
+func (m *MockExpansionDevice) Peek(port int) byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peek", port)
+	ret0, _ := ret[0].(byte)
+	return ret0
+}
+
+// Peek indicates an expected call of Peek
+func (mr *MockExpansionDeviceMockRecorder) Peek(port interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peek", reflect.TypeOf((*MockExpansionDevice)(nil).Peek), port)
+}
+
+// MockInputDevice is a mock of InputDevice interface
+type MockInputDevice struct {
+	ctrl     *gomock.Controller
+	recorder *MockInputDeviceMockRecorder
+}
+
+// MockInputDeviceMockRecorder is the mock recorder for MockInputDevice
+type MockInputDeviceMockRecorder struct {
+	mock *MockInputDevice
+}
+
+// NewMockInputDevice creates a new mock instance
+func NewMockInputDevice(ctrl *gomock.Controller) *MockInputDevice {
+	mock := &MockInputDevice{ctrl: ctrl}
+	mock.recorder = &MockInputDeviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockInputDevice) EXPECT() *MockInputDeviceMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method
+func (m *MockInputDevice) Load() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Load indicates an expected call of Load
+func (mr *MockInputDeviceMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockInputDevice)(nil).Load))
+}
+
+// MockZapperInput is a mock of ZapperInput interface
+type MockZapperInput struct {
+	ctrl     *gomock.Controller
+	recorder *MockZapperInputMockRecorder
+}
+
+// MockZapperInputMockRecorder is the mock recorder for MockZapperInput
+type MockZapperInputMockRecorder struct {
+	mock *MockZapperInput
+}
+
+// NewMockZapperInput creates a new mock instance
+func NewMockZapperInput(ctrl *gomock.Controller) *MockZapperInput {
+	mock := &MockZapperInput{ctrl: ctrl}
+	mock.recorder = &MockZapperInputMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockZapperInput) EXPECT() *MockZapperInputMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method
+func (m *MockZapperInput) Load() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Load indicates an expected call of Load
+func (mr *MockZapperInputMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockZapperInput)(nil).Load))
+}
+
+// GetAim mocks base method
+func (m *MockZapperInput) GetAim() (int, int, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAim")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
+}
+
+// GetAim indicates an expected call of GetAim
+func (mr *MockZapperInputMockRecorder) GetAim() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAim", reflect.TypeOf((*MockZapperInput)(nil).GetAim))
+}
+
+// IsTriggerPulled mocks base method
+func (m *MockZapperInput) IsTriggerPulled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTriggerPulled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsTriggerPulled indicates an expected call of IsTriggerPulled
+func (mr *MockZapperInputMockRecorder) IsTriggerPulled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTriggerPulled", reflect.TypeOf((*MockZapperInput)(nil).IsTriggerPulled))
+}
+
+// MockVausInput is a mock of VausInput interface
+type MockVausInput struct {
+	ctrl     *gomock.Controller
+	recorder *MockVausInputMockRecorder
+}
+
+// MockVausInputMockRecorder is the mock recorder for MockVausInput
+type MockVausInputMockRecorder struct {
+	mock *MockVausInput
+}
+
+// NewMockVausInput creates a new mock instance
+func NewMockVausInput(ctrl *gomock.Controller) *MockVausInput {
+	mock := &MockVausInput{ctrl: ctrl}
+	mock.recorder = &MockVausInputMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVausInput) EXPECT() *MockVausInputMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method
+func (m *MockVausInput) Load() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Load indicates an expected call of Load
+func (mr *MockVausInputMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockVausInput)(nil).Load))
+}
+
+// GetPosition mocks base method
+func (m *MockVausInput) GetPosition() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosition")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetPosition indicates an expected call of GetPosition
+func (mr *MockVausInputMockRecorder) GetPosition() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosition", reflect.TypeOf((*MockVausInput)(nil).GetPosition))
+}
+
+// IsFirePressed mocks base method
+func (m *MockVausInput) IsFirePressed() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFirePressed")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsFirePressed indicates an expected call of IsFirePressed
+func (mr *MockVausInputMockRecorder) IsFirePressed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFirePressed", reflect.TypeOf((*MockVausInput)(nil).IsFirePressed))
+}
+
+// MockKeyboardInput is a mock of KeyboardInput interface
+type MockKeyboardInput struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyboardInputMockRecorder
+}
+
+// MockKeyboardInputMockRecorder is the mock recorder for MockKeyboardInput
+type MockKeyboardInputMockRecorder struct {
+	mock *MockKeyboardInput
+}
+
+// NewMockKeyboardInput creates a new mock instance
+func NewMockKeyboardInput(ctrl *gomock.Controller) *MockKeyboardInput {
+	mock := &MockKeyboardInput{ctrl: ctrl}
+	mock.recorder = &MockKeyboardInputMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockKeyboardInput) EXPECT() *MockKeyboardInputMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method
+func (m *MockKeyboardInput) Load() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Load indicates an expected call of Load
+func (mr *MockKeyboardInputMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockKeyboardInput)(nil).Load))
+}
+
+// IsKeyPressed mocks base method
+func (m *MockKeyboardInput) IsKeyPressed(k domain.KeyboardKey) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsKeyPressed", k)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsKeyPressed indicates an expected call of IsKeyPressed
+func (mr *MockKeyboardInputMockRecorder) IsKeyPressed(k interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKeyPressed", reflect.TypeOf((*MockKeyboardInput)(nil).IsKeyPressed), k)
+}
+
+// MockPowerPadInput is a mock of PowerPadInput interface
+type MockPowerPadInput struct {
+	ctrl     *gomock.Controller
+	recorder *MockPowerPadInputMockRecorder
+}
+
+// MockPowerPadInputMockRecorder is the mock recorder for MockPowerPadInput
+type MockPowerPadInputMockRecorder struct {
+	mock *MockPowerPadInput
+}
+
+// NewMockPowerPadInput creates a new mock instance
+func NewMockPowerPadInput(ctrl *gomock.Controller) *MockPowerPadInput {
+	mock := &MockPowerPadInput{ctrl: ctrl}
+	mock.recorder = &MockPowerPadInputMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPowerPadInput) EXPECT() *MockPowerPadInputMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method
+func (m *MockPowerPadInput) Load() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Load indicates an expected call of Load
+func (mr *MockPowerPadInputMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockPowerPadInput)(nil).Load))
+}
+
+// IsButtonPressed mocks base method
+func (m *MockPowerPadInput) IsButtonPressed(button int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsButtonPressed", button)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsButtonPressed indicates an expected call of IsButtonPressed
+func (mr *MockPowerPadInputMockRecorder) IsButtonPressed(button interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsButtonPressed", reflect.TypeOf((*MockPowerPadInput)(nil).IsButtonPressed), button)
+}
+
 // MockRenderer is a mock of Renderer interface
 type MockRenderer struct {
 	ctrl     *gomock.Controller
@@ -431,3 +1245,39 @@ func (mr *MockRendererMockRecorder) Render(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockRenderer)(nil).Render), arg0)
 }
+
+// SetFrameRate mocks base method
+func (m *MockRenderer) SetFrameRate(arg0 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFrameRate", arg0)
+}
+
+// SetFrameRate indicates an expected call of SetFrameRate
+func (mr *MockRendererMockRecorder) SetFrameRate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFrameRate", reflect.TypeOf((*MockRenderer)(nil).SetFrameRate), arg0)
+}
+
+// AddUpdateHook mocks base method
+func (m *MockRenderer) AddUpdateHook(arg0 func() error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddUpdateHook", arg0)
+}
+
+// AddUpdateHook indicates an expected call of AddUpdateHook
+func (mr *MockRendererMockRecorder) AddUpdateHook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpdateHook", reflect.TypeOf((*MockRenderer)(nil).AddUpdateHook), arg0)
+}
+
+// Close mocks base method
+func (m *MockRenderer) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close
+func (mr *MockRendererMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRenderer)(nil).Close))
+}
@@ -0,0 +1,139 @@
+package it
+
+import (
+	"bytes"
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl"
+	"nes-go/pkg/log"
+	"nes-go/pkg/mock_domain"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	// HELLO_WORLD_ROM_PATH ... nestestは1フレームで終わるので、複数フレームを実行するテストではこちらを使う
+	HELLO_WORLD_ROM_PATH = "../roms/hello-world/hello-world.nes"
+)
+
+// framePad ... フレームごとにAボタンの押下を切り替えるパッド（Loadの回数でフレーム数を数える）
+type framePad struct {
+	frames int
+}
+
+func (p *framePad) Load() error {
+	p.frames++
+	return nil
+}
+
+func (p *framePad) IsPressed(b domain.ButtonType) bool {
+	return b == domain.ButtonTypeA && p.frames%2 == 1
+}
+
+// setupHelloWorld ... hello-worldを読み込んだNESを生成
+func setupHelloWorld(t *testing.T, ctrl *gomock.Controller, pad domain.Pad, recorder *domain.Recorder) *domain.NES {
+	t.Helper()
+
+	// hello-worldはVBlankを待たずにPPUへ書き込むので、PPUのウォームアップをなくす
+	profile := domain.DefaultPowerOnProfile()
+	profile.PPUWarmUpCycles = 0
+
+	nes := &domain.NES{
+		Bus:      impl.NewBus(),
+		CPU:      impl.NewCPU(nil),
+		PPU:      impl.NewPPU2(),
+		Pad1:     pad,
+		Pad2:     &framePad{},
+		Renderer: mock_domain.NewMockRenderer(ctrl),
+		Recorder: recorder,
+		PowerOn:  &profile,
+	}
+	if err := nes.Setup(HELLO_WORLD_ROM_PATH); err != nil {
+		t.Fatalf("failed to setup; %v", err)
+	}
+	return nes
+}
+
+func TestMovieRerecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log.SetLogLevel(log.LevelWarn)
+
+	pad := &framePad{}
+	recorder := &domain.Recorder{}
+	nes := setupHelloWorld(t, ctrl, pad, recorder)
+
+	// 記録中はフレームの先頭でパッドを読み込むので、padのLoadの回数がフレーム数になる
+	steps := 0
+	runFrames := func(frames int) []string {
+		logs := []string{}
+		until := pad.frames + frames
+		for pad.frames < until {
+			if err := nes.Run1Cycle(); err != nil {
+				t.Fatalf("failed to run, %v", err)
+			}
+			logs = append(logs, recorder.String())
+			steps++
+		}
+		return logs
+	}
+
+	if err := nes.StartMovieRecording(false); err != nil {
+		t.Fatalf("failed to start recording; %v", err)
+	}
+
+	runFrames(3)
+	savedSteps := steps
+
+	var buf bytes.Buffer
+	if err := nes.SaveState(&buf); err != nil {
+		t.Fatalf("failed to save state; %v", err)
+	}
+
+	runFrames(5)
+
+	if err := nes.LoadState(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("failed to load state; %v", err)
+	}
+	// padのフレーム数は巻き戻らないので、読み込み後は捨てたフレームと逆の押下を記録する
+	want := runFrames(4)
+
+	m := nes.StopMovie()
+	if m == nil {
+		t.Fatalf("movie is not recorded")
+	}
+	if len(m.Frames) != 7 {
+		t.Errorf("wrong frames\nwant: %#v\ngot: %#v", 7, len(m.Frames))
+	}
+	if m.RerecordCount != 1 {
+		t.Errorf("wrong rerecord count\nwant: %#v\ngot: %#v", 1, m.RerecordCount)
+	}
+	for i, f := range m.Frames[3:] {
+		pressed := (3+i)%2 == 1
+		if got := f.Pads[0].IsPressed(domain.ButtonTypeA); got != pressed {
+			t.Errorf("wrong pad\nframe: %v\nwant: %#v\ngot: %#v", 3+i, pressed, got)
+		}
+	}
+
+	// 再生すると、読み込み後に記録し直したフレームと同じ実行になる
+	if err := nes.PlayMovie(m); err != nil {
+		t.Fatalf("failed to play movie; %v", err)
+	}
+	for i := 0; i < savedSteps; i++ {
+		if err := nes.Run1Cycle(); err != nil {
+			t.Fatalf("failed to run, %v", err)
+		}
+	}
+	for i := range want {
+		if err := nes.Run1Cycle(); err != nil {
+			t.Fatalf("failed to run, %v", err)
+		}
+		if got := recorder.String(); got != want[i] {
+			t.Fatalf("wrong output\nstep: %v\nwant: %#v\ngot: %#v", i, want[i], got)
+		}
+	}
+	if !nes.IsMoviePlaying() {
+		t.Errorf("movie playback finished before the recorded frames")
+	}
+}
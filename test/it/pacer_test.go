@@ -0,0 +1,78 @@
+package it
+
+import (
+	"context"
+	"nes-go/pkg/domain"
+	"nes-go/pkg/log"
+	"nes-go/pkg/mock_domain"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNESAdvanceFrameWhilePaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log.SetLogLevel(log.LevelWarn)
+
+	nes := setupHelloWorld(t, ctrl, &framePad{}, &domain.Recorder{})
+
+	// 描画したフレームをframesに送り、Closeされるまでウィンドウが開いているように振る舞う
+	frames := make(chan *domain.Screen, 8)
+	closed := make(chan struct{})
+	renderer := mock_domain.NewMockRenderer(ctrl)
+	renderer.EXPECT().SetFrameRate(gomock.Any()).AnyTimes()
+	renderer.EXPECT().Render(gomock.Any()).DoAndReturn(func(s *domain.Screen) error {
+		frames <- s
+		return nil
+	}).AnyTimes()
+	renderer.EXPECT().Run().DoAndReturn(func() error {
+		<-closed
+		return nil
+	})
+	renderer.EXPECT().Close().Do(func() {
+		close(closed)
+	})
+	nes.Renderer = renderer
+
+	nes.Pause()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- nes.Run(ctx)
+	}()
+
+	// 一時停止中はフレームを進めない
+	const wait = 200 * time.Millisecond
+	select {
+	case <-frames:
+		t.Fatalf("frame is rendered while paused")
+	case <-time.After(wait):
+	}
+
+	for i := 0; i < 2; i++ {
+		nes.AdvanceFrame()
+
+		select {
+		case <-frames:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("frame is not rendered after advance")
+		}
+		select {
+		case <-frames:
+			t.Fatalf("more than one frame is rendered after advance")
+		case <-time.After(wait):
+		}
+		if !nes.IsPaused() {
+			t.Errorf("wrong paused\nwant: %#v\ngot: %#v", true, false)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+}
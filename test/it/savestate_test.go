@@ -0,0 +1,157 @@
+package it
+
+import (
+	"bytes"
+	"image/color"
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl"
+	"nes-go/pkg/log"
+	"nes-go/pkg/mock_domain"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestSaveState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log.SetLogLevel(log.LevelWarn)
+
+	firstPC := uint16(FIRST_PC)
+	mRenderer := mock_domain.NewMockRenderer(ctrl)
+	recorder := &domain.Recorder{}
+
+	nes := domain.NES{
+		Bus:      impl.NewBus(),
+		CPU:      impl.NewCPU(&firstPC),
+		PPU:      impl.NewPPU2(),
+		Pad1:     mock_domain.NewMockPad(ctrl),
+		Pad2:     mock_domain.NewMockPad(ctrl),
+		Renderer: mRenderer,
+		Recorder: recorder,
+	}
+	if err := nes.Setup(ROM_PATH); err != nil {
+		t.Fatalf("failed to setup; %v", err)
+	}
+
+	run := func(steps int) []string {
+		logs := []string{}
+		for i := 0; i < steps; i++ {
+			if err := nes.Run1Cycle(); err != nil {
+				t.Fatalf("failed to run, %v", err)
+			}
+			logs = append(logs, recorder.String())
+		}
+		return logs
+	}
+
+	run(1000)
+
+	var buf bytes.Buffer
+	if err := nes.SaveState(&buf); err != nil {
+		t.Fatalf("failed to save state; %v", err)
+	}
+	state := buf.Bytes()
+
+	want := run(1000)
+
+	if err := nes.LoadState(bytes.NewReader(state)); err != nil {
+		t.Fatalf("failed to load state; %v", err)
+	}
+
+	got := run(1000)
+
+	for i := range want {
+		if want[i] != got[i] {
+			t.Fatalf("wrong output\nstep: %v\nwant: %#v\ngot: %#v", i, want[i], got[i])
+		}
+	}
+
+	// 形式が異なるデータは読み込めない
+	broken := append([]byte{}, state...)
+	broken[8] = 0xFF
+	if err := nes.LoadState(bytes.NewReader(broken)); err == nil {
+		t.Errorf("state with another version is loaded")
+	}
+}
+
+func TestSaveStateAcrossFrames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log.SetLogLevel(log.LevelWarn)
+
+	recorder := &domain.Recorder{}
+	nes := setupHelloWorld(t, ctrl, &framePad{}, recorder)
+
+	// post-render lineに入るたびに完成したフレームの画素を取り出す
+	type frame [domain.ResolutionHeight][domain.ResolutionWidth]color.RGBA
+	run := func(steps int) ([]string, []frame) {
+		logs := []string{}
+		frames := []frame{}
+		for i := 0; i < steps; i++ {
+			scanline := recorder.Scanline
+			if err := nes.Run1Cycle(); err != nil {
+				t.Fatalf("failed to run, %v", err)
+			}
+			logs = append(logs, recorder.String())
+
+			if scanline == domain.ResolutionHeight || recorder.Scanline != domain.ResolutionHeight {
+				continue
+			}
+			f := frame{}
+			for y := range f {
+				for x := range f[y] {
+					f[y][x], _, _ = nes.PPU.GetOutputPixel(x, y)
+				}
+			}
+			frames = append(frames, f)
+		}
+		return logs, frames
+	}
+
+	// フレームの途中で保存し、VBlankを2回以上またいで実行する
+	run(15000)
+
+	var buf bytes.Buffer
+	if err := nes.SaveState(&buf); err != nil {
+		t.Fatalf("failed to save state; %v", err)
+	}
+
+	wantLogs, wantFrames := run(25000)
+	if len(wantFrames) < 2 {
+		t.Fatalf("wrong frames\nwant: >= %#v\ngot: %#v", 2, len(wantFrames))
+	}
+	// 背景色だけの画面では比較にならないので、文字が描画されていることを確認する
+	colors := map[color.RGBA]bool{}
+	for _, row := range wantFrames[0] {
+		for _, c := range row {
+			colors[c] = true
+		}
+	}
+	if len(colors) < 2 {
+		t.Fatalf("screen is not rendered; colors: %v", colors)
+	}
+
+	if err := nes.LoadState(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("failed to load state; %v", err)
+	}
+
+	gotLogs, gotFrames := run(25000)
+
+	for i := range wantLogs {
+		if wantLogs[i] != gotLogs[i] {
+			t.Fatalf("wrong output\nstep: %v\nwant: %#v\ngot: %#v", i, wantLogs[i], gotLogs[i])
+		}
+	}
+	if len(gotFrames) != len(wantFrames) {
+		t.Fatalf("wrong frames\nwant: %#v\ngot: %#v", len(wantFrames), len(gotFrames))
+	}
+	for i := range wantFrames {
+		if !reflect.DeepEqual(wantFrames[i], gotFrames[i]) {
+			t.Errorf("wrong screen\nframe: %v", i)
+		}
+	}
+}
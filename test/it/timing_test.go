@@ -0,0 +1,216 @@
+package it
+
+import (
+	"io/ioutil"
+	"nes-go/pkg/domain"
+	"nes-go/pkg/impl"
+	"nes-go/pkg/log"
+	"nes-go/pkg/mock_domain"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// writeTestROM ... $C000から始まるプログラムと、$FF00から始まるNMIのハンドラを書き込んだNROM（PRG 16KB・CHR 8KB）を作る
+func writeTestROM(t *testing.T, dir string, program []byte, nmi []byte) string {
+	t.Helper()
+
+	prg := make([]byte, 0x4000)
+	copy(prg, program)
+	copy(prg[0x3F00:], nmi)
+	// NMI・RESET・IRQのベクタ
+	copy(prg[0x3FFA:], []byte{0x00, 0xFF, 0x00, 0xC0, 0x00, 0xC0})
+
+	data := append([]byte{'N', 'E', 'S', 0x1A, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, prg...)
+	data = append(data, make([]byte, 0x2000)...)
+
+	p := filepath.Join(dir, "test.nes")
+	if err := ioutil.WriteFile(p, data, 0644); err != nil {
+		t.Fatalf("failed to write rom: %v", err)
+	}
+	return p
+}
+
+// setupTestROM ... writeTestROMで作ったROMを読み込んだNESを生成（PPUのウォームアップはなくす）
+func setupTestROM(t *testing.T, ctrl *gomock.Controller, program []byte, nmi []byte, recorder *domain.Recorder) *domain.NES {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "timing")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	profile := domain.DefaultPowerOnProfile()
+	profile.PPUWarmUpCycles = 0
+
+	nes := &domain.NES{
+		Bus:      impl.NewBus(),
+		CPU:      impl.NewCPU(nil),
+		PPU:      impl.NewPPU2(),
+		Pad1:     &framePad{},
+		Pad2:     &framePad{},
+		Renderer: mock_domain.NewMockRenderer(ctrl),
+		Recorder: recorder,
+		PowerOn:  &profile,
+	}
+	if err := nes.Setup(writeTestROM(t, dir, program, nmi)); err != nil {
+		t.Fatalf("failed to setup; %v", err)
+	}
+	return nes
+}
+
+func TestNESCPUTiming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log.SetLogLevel(log.LevelWarn)
+
+	program := []byte{
+		0xA9, 0x02, // C000: LDA #$02
+		0x8D, 0x14, 0x40, // C002: STA $4014（奇数サイクルから始まるDMA）
+		0xE6, 0x00, // C005: INC $00
+		0x8D, 0x14, 0x40, // C007: STA $4014（偶数サイクルから始まるDMA）
+		0xEA,             // C00A: NOP
+		0xAD, 0x02, 0x20, // C00B: LDA $2002
+		0xEA,             // C00E: NOP
+		0x4C, 0x0F, 0xC0, // C00F: JMP $C00F
+	}
+
+	recorder := &domain.Recorder{}
+	nes := setupTestROM(t, ctrl, program, nil, recorder)
+
+	type step struct {
+		cycle int // 命令を開始したCPUサイクル
+		dots  int // 命令の実行後のPPUの位置（フレームの先頭からのdot数）
+	}
+	steps := map[uint16]step{}
+	for i := 0; i < 9; i++ {
+		if err := nes.Run1Cycle(); err != nil {
+			t.Fatalf("failed to run, %v", err)
+		}
+		steps[recorder.PC] = step{
+			cycle: recorder.Cycle,
+			dots:  int(recorder.Scanline)*341 + int(recorder.Dot),
+		}
+	}
+
+	t.Run("when OAM DMA starts, CPU stalls 514 cycles on odd cycle and 513 cycles on even cycle", func(t *testing.T) {
+		tests := []struct {
+			from, to uint16
+			want     int
+		}{
+			{from: 0xC002, to: 0xC005, want: 4 + 514},
+			{from: 0xC005, to: 0xC007, want: 5},
+			{from: 0xC007, to: 0xC00A, want: 4 + 513},
+		}
+		for _, tt := range tests {
+			got := steps[tt.to].cycle - steps[tt.from].cycle
+			if got != tt.want {
+				t.Errorf("wrong output\nfrom: %#04X\nwant: %#v\ngot: %#v", tt.from, tt.want, got)
+			}
+		}
+	})
+
+	t.Run("when CPU reads PPU register, PPU catches up to the access cycle", func(t *testing.T) {
+		// 命令の先頭までしか進んでいないNOPに比べて、LDA $2002は4サイクル目（3サイクル後）までPPUが進む
+		offset := func(pc uint16) int {
+			return steps[pc].dots - steps[pc].cycle*3
+		}
+		want := offset(0xC00A) + 3*3
+		if got := offset(0xC00B); got != want {
+			t.Errorf("wrong output\nwant: %#v\ngot: %#v", want, got)
+		}
+		if got := offset(0xC00E); got != offset(0xC00A) {
+			t.Errorf("wrong output\nwant: %#v\ngot: %#v", offset(0xC00A), got)
+		}
+	})
+}
+
+func TestNESVBlankRace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log.SetLogLevel(log.LevelWarn)
+
+	const vblank = 241 * 341 // VBlankのスキャンラインの先頭（フレームの先頭からのdot数）
+
+	// delayサイクル待ってからPPUSTATUSを読み、読んだ値を$10、NMIの回数を$12に書き込む
+	// 読んだときにPPUが次に実行するdotを、VBlankのスキャンラインの先頭からのdot数で返す
+	run := func(delay int) (dot int, read bool, nmi bool) {
+		program := []byte{0xA9, 0x80, 0x8D, 0x00, 0x20} // LDA #$80; STA $2000（NMIを有効にする）
+		if delay%2 == 1 {
+			program = append(program, 0xA5, 0x00) // LDA $00（3サイクル）
+			delay = delay - 3
+		}
+		for i := 0; i < delay/2; i++ {
+			program = append(program, 0xEA) // NOP
+		}
+		readPC := uint16(0xC000 + len(program))
+		program = append(program, 0xAD, 0x02, 0x20, 0x85, 0x10) // LDA $2002; STA $10
+		loopPC := uint16(0xC000 + len(program))
+		program = append(program, 0x4C, byte(loopPC), byte(loopPC>>8)) // JMP loopPC
+		handler := []byte{0xE6, 0x12, 0x40}                            // INC $12; RTI
+
+		recorder := &domain.Recorder{}
+		nes := setupTestROM(t, ctrl, program, handler, recorder)
+		if err := nes.Bus.WriteByCPU(0x12, 0); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+
+		// 読んだ後、NMIが発生するdotを過ぎるまで進める
+		loops := 0
+		offset := 0 // PPUのdot数とCPUのサイクル数の3倍との差
+		for i := 0; loops < 10; i++ {
+			if i > 100000 {
+				t.Fatalf("PPUSTATUS is not read")
+			}
+			if err := nes.Run1Cycle(); err != nil {
+				t.Fatalf("failed to run, %v", err)
+			}
+			// PPUのレジスタにアクセスしない命令の後は、PPUは命令の先頭まで進んでいる
+			// LDA $2002は4サイクル目（3サイクル後）に読むため、そこからPPUが次に実行するdotを求める
+			pos := int(recorder.Scanline)*341 + int(recorder.Dot)
+			switch recorder.PC {
+			case readPC - 1:
+				offset = pos - recorder.Cycle*3
+			case readPC:
+				dot = (recorder.Cycle+3)*3 + offset - vblank
+			case loopPC:
+				loops++
+			}
+		}
+
+		status, err := nes.Bus.ReadByCPU(0x10)
+		if err != nil {
+			t.Fatalf("failed to read: %v", err)
+		}
+		count, err := nes.Bus.ReadByCPU(0x12)
+		if err != nil {
+			t.Fatalf("failed to read: %v", err)
+		}
+		return dot, status&0x80 == 0x80, count > 0
+	}
+
+	// PPUとCPUのクロックの比は3なので、待つサイクルを変えながらVBlankの前後を1サイクルずつ読む
+	start, _, _ := run(2)
+	center := 2 - start/3
+	hit := map[int]bool{}
+	for delay := center - 4; delay <= center+4; delay++ {
+		dot, gotRead, gotNMI := run(delay)
+		hit[dot] = true
+
+		// ppu2_testのTestPPU2VBlankRaceと同じ結果になる（dotは読んだときにPPUが次に実行するdot）
+		wantRead := dot >= 2
+		wantNMI := dot <= 0 || dot >= 4
+		if gotRead != wantRead || gotNMI != wantNMI {
+			t.Errorf("wrong output\ndelay: %v, dot: %v\nwant: read=%#v, nmi=%#v\ngot: read=%#v, nmi=%#v", delay, dot, wantRead, wantNMI, gotRead, gotNMI)
+		}
+	}
+	// フラグがセットされる直前に読んで、フラグもNMIも発生しない場合を含める
+	if !hit[1] {
+		t.Errorf("PPUSTATUS is not read just before the flag is set\ndots: %v", hit)
+	}
+}